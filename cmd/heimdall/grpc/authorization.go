@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/getsentry/sentry-go"
+	authv3 "github.com/thetkpark/heimdall/cmd/heimdall/proto/envoy/service/auth/v3"
+	"github.com/thetkpark/heimdall/pkg/authz"
+	"github.com/thetkpark/heimdall/pkg/header"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+var (
+	TokenFormatError   = token.TokenFormatError
+	TokenParsingError  = errors.New("failed to parse token")
+	ClaimHeaderError   = errors.New("failed to format claim headers")
+	AuthorizationError = errors.New("failed to authorize request")
+)
+
+// NewAuthorizationServer returns the Envoy external authorization server,
+// which allows the requests of valid bearer tokens that the policy authorizes and sets their claims to the headers of headerMapper.
+// The headers of headerMapper that the claims do not set are removed from the requests, so the clients cannot spoof them.
+// The tokens are read from the headers or the query of the requests by extractor.
+func NewAuthorizationServer(logger *zap.SugaredLogger, tokenMng token.Manager, policy *authz.Policy, headerMapper *header.Mapper, extractor *token.Extractor) *AuthorizationServer {
+	return &AuthorizationServer{
+		logger:       logger,
+		tokenManager: tokenMng,
+		policy:       policy,
+		headerMapper: headerMapper,
+		extractor:    extractor,
+	}
+}
+
+type AuthorizationServer struct {
+	authv3.UnimplementedAuthorizationServer
+	logger       *zap.SugaredLogger
+	tokenManager token.Manager
+	policy       *authz.Policy
+	headerMapper *header.Mapper
+	extractor    *token.Extractor
+}
+
+func (s AuthorizationServer) Check(_ context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	// Envoy lower-cases the header names
+	requestHeader := make(http.Header, len(httpReq.GetHeaders()))
+	for name, value := range httpReq.GetHeaders() {
+		requestHeader.Set(name, value)
+	}
+	var query url.Values
+	if requestURI, err := url.ParseRequestURI(httpReq.GetPath()); err == nil {
+		query = requestURI.Query()
+	}
+	tokenString, err := s.extractor.Extract(requestHeader, query)
+	if err != nil {
+		return deniedResponse(codes.Unauthenticated, authv3.StatusCode_Unauthorized, err), nil
+	}
+	payload, err := s.tokenManager.Parse(tokenString)
+	if err != nil {
+		var validationErr *token.ValidationError
+		if errors.As(err, &validationErr) {
+			return deniedResponse(codes.Unauthenticated, authv3.StatusCode_Unauthorized, validationErr.Err), nil
+		}
+		return deniedResponse(codes.Unauthenticated, authv3.StatusCode_Unauthorized, TokenParsingError), nil
+	}
+
+	authzReq := authz.NewRequest(httpReq.GetMethod(), httpReq.GetPath(), httpReq.GetHeaders(),
+		req.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress())
+	rule, err := s.policy.Match(authzReq)
+	if err != nil {
+		return deniedResponse(codes.PermissionDenied, authv3.StatusCode_Forbidden, err), nil
+	}
+	if rule != nil {
+		if err := rule.Authorize(authzReq, payload); err != nil {
+			switch {
+			case errors.Is(err, authz.ConditionFailedError):
+				// The evaluation errors are only logged, as they reveal the condition
+				if err != authz.ConditionFailedError {
+					s.logger.Warnw("Failed to evaluate policy condition", "error", err, "condition", rule.Condition)
+				}
+				err = authz.ConditionFailedError
+			case !errors.Is(err, authz.InsufficientScopeError) && !errors.Is(err, authz.InsufficientRoleError):
+				sentry.CaptureException(err)
+				s.logger.Errorw("rule.Authorize error", "error", err)
+				return deniedResponse(codes.Internal, authv3.StatusCode_InternalServerError, AuthorizationError), nil
+			}
+			return deniedResponse(codes.PermissionDenied, authv3.StatusCode_Forbidden, err), nil
+		}
+	}
+
+	headers, err := s.headerMapper.Headers(payload)
+	if err != nil {
+		sentry.CaptureException(err)
+		s.logger.Errorw("headerMapper.Headers error", "error", err)
+		return deniedResponse(codes.Internal, authv3.StatusCode_InternalServerError, ClaimHeaderError), nil
+	}
+	setHeaders := make(map[string]bool, len(headers))
+	for name := range headers {
+		setHeaders[strings.ToLower(name)] = true
+	}
+	var headersToRemove []string
+	for _, name := range s.headerMapper.Names() {
+		name = strings.ToLower(name)
+		if _, ok := httpReq.GetHeaders()[name]; ok && !setHeaders[name] {
+			headersToRemove = append(headersToRemove, name)
+		}
+	}
+	return &authv3.CheckResponse{
+		Status: &authv3.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{
+			OkResponse: &authv3.OkHttpResponse{Headers: headerValueOptions(headers), HeadersToRemove: headersToRemove},
+		},
+	}, nil
+}
+
+// deniedResponse responds the downstream client with the status and the error in the body, as the REST API does.
+func deniedResponse(code codes.Code, httpStatus authv3.StatusCode, err error) *authv3.CheckResponse {
+	body, _ := json.Marshal(map[string]string{"error": err.Error()})
+	headers := map[string]string{"Content-Type": "application/json"}
+	if httpStatus == authv3.StatusCode_Unauthorized {
+		headers["WWW-Authenticate"] = "Bearer"
+	}
+	return &authv3.CheckResponse{
+		Status: &authv3.Status{Code: int32(code), Message: err.Error()},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{
+			DeniedResponse: &authv3.DeniedHttpResponse{
+				Status:  &authv3.HttpStatus{Code: httpStatus},
+				Headers: headerValueOptions(headers),
+				Body:    string(body),
+			},
+		},
+	}
+}
+
+func headerValueOptions(headers map[string]string) []*authv3.HeaderValueOption {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	options := make([]*authv3.HeaderValueOption, 0, len(names))
+	for _, name := range names {
+		options = append(options, &authv3.HeaderValueOption{
+			Header: &authv3.HeaderValue{Key: name, Value: headers[name]},
+		})
+	}
+	return options
+}
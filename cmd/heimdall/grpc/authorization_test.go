@@ -0,0 +1,254 @@
+package grpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/cmd/heimdall/grpc"
+	authv3 "github.com/thetkpark/heimdall/cmd/heimdall/proto/envoy/service/auth/v3"
+	"github.com/thetkpark/heimdall/pkg/authz"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/header"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"github.com/thetkpark/heimdall/test/mock_token"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+var _ = Describe("AuthorizationServer_gRPC", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockTokenManager *mock_token.MockManager
+		authServer       *grpc.AuthorizationServer
+		headers          map[string]string
+		path             string
+		res              *authv3.CheckResponse
+		resError         error
+		responseHeaders  = func(options []*authv3.HeaderValueOption) map[string]string {
+			headers := map[string]string{}
+			for _, option := range options {
+				headers[option.GetHeader().GetKey()] = option.GetHeader().GetValue()
+			}
+			return headers
+		}
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockTokenManager = mock_token.NewMockManager(mockCtrl)
+		policy, err := authz.NewPolicy(
+			authz.Rule{Path: "/admin/**", Roles: []string{"admin"}},
+			authz.Rule{Path: "/tenants/*/**", Condition: `claims.tenant == request.path.split('/')[2] && request.ip.inCIDR('10.0.0.0/8')`},
+		)
+		Expect(err).To(BeNil())
+		authServer = grpc.NewAuthorizationServer(zap.NewNop().Sugar(), mockTokenManager, policy, nil, nil)
+		headers = map[string]string{"authorization": "Bearer valid.token.string"}
+		path = "/"
+	})
+
+	JustBeforeEach(func() {
+		res, resError = authServer.Check(context.Background(), &authv3.CheckRequest{
+			Attributes: &authv3.AttributeContext{
+				Source: &authv3.AttributeContext_Peer{
+					Address: &authv3.Address{SocketAddress: &authv3.SocketAddress{Address: "10.0.0.7", PortValue: 51234}},
+				},
+				Request: &authv3.AttributeContext_Request{
+					Http: &authv3.AttributeContext_HttpRequest{Method: "GET", Path: path, Headers: headers},
+				},
+			},
+		})
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	When("Token is valid", func() {
+		BeforeEach(func() {
+			mockTokenManager.EXPECT().Parse("valid.token.string").Return(&config.Payload{
+				CustomPayload: config.CustomPayload{"user_id": json.Number("99"), "roles": []interface{}{"admin"}},
+			}, nil).Times(1)
+		})
+
+		It("should allow the request with the claim headers", func() {
+			Expect(resError).To(BeNil())
+			Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.OK)))
+			Expect(responseHeaders(res.GetOkResponse().GetHeaders())).To(Equal(map[string]string{
+				"X-USER-ID": "99",
+				"X-ROLES":   "admin",
+			}))
+			Expect(res.GetOkResponse().GetHeadersToRemove()).To(BeEmpty())
+		})
+
+		When("Claim headers are the defaults of the claims schema", func() {
+			BeforeEach(func() {
+				policy, err := authz.NewPolicy()
+				Expect(err).To(BeNil())
+				mapper := header.NewDefaultMapper([]string{"user_id", "roles", "tenant"})
+				authServer = grpc.NewAuthorizationServer(zap.NewNop().Sugar(), mockTokenManager, policy, mapper, nil)
+				headers["x-tenant"] = "spoofed"
+				headers["x-user-id"] = "1"
+			})
+
+			It("should set the claim headers and remove the spoofed ones", func() {
+				Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.OK)))
+				Expect(responseHeaders(res.GetOkResponse().GetHeaders())).To(Equal(map[string]string{
+					"X-USER-ID": "99",
+					"X-ROLES":   "admin",
+				}))
+				Expect(res.GetOkResponse().GetHeadersToRemove()).To(Equal([]string{"x-tenant"}))
+			})
+		})
+
+		When("Claim headers are mapped", func() {
+			BeforeEach(func() {
+				mapper, err := header.NewMapper(header.MapperConfig{Headers: []header.Mapping{
+					{Claim: "user_id", Header: "X-User-Id"},
+					{Claim: "tenant", Header: "X-Tenant"},
+				}})
+				Expect(err).To(BeNil())
+				policy, err := authz.NewPolicy()
+				Expect(err).To(BeNil())
+				authServer = grpc.NewAuthorizationServer(zap.NewNop().Sugar(), mockTokenManager, policy, mapper, nil)
+				headers["x-tenant"] = "spoofed"
+				headers["x-user-id"] = "1"
+			})
+
+			It("should set the mapped headers and remove the spoofed ones", func() {
+				Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.OK)))
+				Expect(responseHeaders(res.GetOkResponse().GetHeaders())).To(Equal(map[string]string{"X-User-Id": "99"}))
+				Expect(res.GetOkResponse().GetHeadersToRemove()).To(Equal([]string{"x-tenant"}))
+			})
+		})
+	})
+
+	When("Token is in a custom header of the configured sources", func() {
+		BeforeEach(func() {
+			extractor, err := token.ParseExtractor("query:access_token,header:X-Auth-Token")
+			Expect(err).To(BeNil())
+			policy, err := authz.NewPolicy()
+			Expect(err).To(BeNil())
+			authServer = grpc.NewAuthorizationServer(zap.NewNop().Sugar(), mockTokenManager, policy, nil, extractor)
+			headers = map[string]string{"x-auth-token": "header.valid.token"}
+		})
+
+		When("Token is only in the header", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("header.valid.token").Return(&config.Payload{
+					CustomPayload: config.CustomPayload{"user_id": json.Number("99")},
+				}, nil).Times(1)
+			})
+
+			It("should allow the request", func() {
+				Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.OK)))
+				Expect(responseHeaders(res.GetOkResponse().GetHeaders())).To(Equal(map[string]string{"X-USER-ID": "99"}))
+			})
+		})
+
+		When("Token is also in the query", func() {
+			BeforeEach(func() {
+				path = "/ws?access_token=query.invalid"
+			})
+
+			It("should deny the token of the first source", func() {
+				Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.Unauthenticated)))
+			})
+		})
+	})
+
+	When("Token satisfies the condition of the rule", func() {
+		BeforeEach(func() {
+			path = "/tenants/acme/invoices?page=2"
+			mockTokenManager.EXPECT().Parse("valid.token.string").Return(&config.Payload{
+				CustomPayload: config.CustomPayload{"tenant": "acme"},
+			}, nil).Times(1)
+		})
+
+		It("should allow the request", func() {
+			Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.OK)))
+		})
+	})
+
+	When("Token does not satisfy the condition of the rule", func() {
+		BeforeEach(func() {
+			path = "/tenants/globex/invoices"
+			mockTokenManager.EXPECT().Parse("valid.token.string").Return(&config.Payload{
+				CustomPayload: config.CustomPayload{"tenant": "acme"},
+			}, nil).Times(1)
+		})
+
+		It("should deny the request with 403", func() {
+			Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.PermissionDenied)))
+			Expect(res.GetDeniedResponse().GetStatus().GetCode()).To(Equal(authv3.StatusCode_Forbidden))
+			Expect(res.GetDeniedResponse().GetBody()).To(MatchJSON(`{"error": "policy condition is not satisfied"}`))
+		})
+	})
+
+	When("Token does not have the roles of the rule", func() {
+		BeforeEach(func() {
+			path = "/admin/users"
+			mockTokenManager.EXPECT().Parse("valid.token.string").Return(&config.Payload{
+				CustomPayload: config.CustomPayload{"roles": "viewer"},
+			}, nil).Times(1)
+		})
+
+		It("should deny the request with 403", func() {
+			Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.PermissionDenied)))
+			Expect(res.GetDeniedResponse().GetBody()).To(MatchJSON(`{"error": "insufficient role"}`))
+		})
+	})
+
+	When("Request path is missing", func() {
+		BeforeEach(func() {
+			path = ""
+			mockTokenManager.EXPECT().Parse("valid.token.string").Return(&config.Payload{
+				CustomPayload: config.CustomPayload{"roles": "admin"},
+			}, nil).Times(1)
+		})
+
+		It("should deny the request with 403", func() {
+			Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.PermissionDenied)))
+			Expect(res.GetDeniedResponse().GetBody()).To(MatchJSON(`{"error": "missing request path"}`))
+		})
+	})
+
+	When("Token is missing", func() {
+		BeforeEach(func() {
+			headers = map[string]string{}
+		})
+
+		It("should deny the request with 401", func() {
+			Expect(resError).To(BeNil())
+			Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.Unauthenticated)))
+			Expect(res.GetDeniedResponse().GetStatus().GetCode()).To(Equal(authv3.StatusCode_Unauthorized))
+			Expect(res.GetDeniedResponse().GetBody()).To(MatchJSON(`{"error": "token format is invalid"}`))
+			Expect(responseHeaders(res.GetDeniedResponse().GetHeaders())).To(HaveKeyWithValue("WWW-Authenticate", "Bearer"))
+		})
+	})
+
+	When("Token is expired", func() {
+		BeforeEach(func() {
+			mockTokenManager.EXPECT().Parse("valid.token.string").Return(nil, &token.ValidationError{Err: token.TokenExpiredError}).Times(1)
+		})
+
+		It("should deny the request with the reason", func() {
+			Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.Unauthenticated)))
+			Expect(res.GetStatus().GetMessage()).To(Equal(token.TokenExpiredError.Error()))
+			Expect(res.GetDeniedResponse().GetStatus().GetCode()).To(Equal(authv3.StatusCode_Unauthorized))
+		})
+	})
+
+	When("Token is invalid", func() {
+		BeforeEach(func() {
+			mockTokenManager.EXPECT().Parse("valid.token.string").Return(nil, errors.New("invalid signature")).Times(1)
+		})
+
+		It("should deny the request", func() {
+			Expect(res.GetStatus().GetCode()).To(Equal(int32(codes.Unauthenticated)))
+			Expect(res.GetDeniedResponse().GetBody()).To(MatchJSON(`{"error": "failed to parse token"}`))
+		})
+	})
+})
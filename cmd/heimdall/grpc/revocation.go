@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"github.com/getsentry/sentry-go"
+	pb "github.com/thetkpark/heimdall/cmd/heimdall/proto"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// NewRevocationServer returns the server that revokes tokens in the store for the callers authenticated by issuers.
+// Revoked jti are kept for the retention, or forever when it is zero.
+func NewRevocationServer(logger *zap.SugaredLogger, store revocation.Store, issuers *client.IssuerAuthenticator, retention time.Duration) *RevocationServer {
+	return &RevocationServer{
+		logger:          logger,
+		revocationStore: store,
+		issuers:         issuers,
+		retention:       retention,
+	}
+}
+
+type RevocationServer struct {
+	pb.UnimplementedRevocationServer
+	logger          *zap.SugaredLogger
+	revocationStore revocation.Store
+	issuers         *client.IssuerAuthenticator
+	retention       time.Duration
+}
+
+func (s RevocationServer) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevocationResponse, error) {
+	if _, err := authenticateCaller(ctx, s.issuers); err != nil {
+		return nil, err
+	}
+	err := req.ValidateAll()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var expiredAt time.Time
+	if s.retention > 0 {
+		expiredAt = time.Now().Add(s.retention)
+	}
+	if err := s.revocationStore.RevokeToken(ctx, req.GetID(), expiredAt); err != nil {
+		sentry.CaptureException(err)
+		s.logger.Errorw("s.revocationStore.RevokeToken error", "error", err, "jti", req.GetID())
+		return nil, status.Error(codes.Internal, "Failed to revoke token")
+	}
+	return &pb.RevocationResponse{}, nil
+}
+
+func (s RevocationServer) RevokeUserTokens(ctx context.Context, req *pb.RevokeUserTokensRequest) (*pb.RevocationResponse, error) {
+	if _, err := authenticateCaller(ctx, s.issuers); err != nil {
+		return nil, err
+	}
+	err := req.ValidateAll()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	issuedBefore := time.Now()
+	if req.GetIssuedBefore() != 0 {
+		issuedBefore = time.Unix(req.GetIssuedBefore(), 0)
+	}
+	if err := s.revocationStore.RevokeSubject(ctx, req.GetUserID(), issuedBefore); err != nil {
+		sentry.CaptureException(err)
+		s.logger.Errorw("s.revocationStore.RevokeSubject error", "error", err, "user_id", req.GetUserID())
+		return nil, status.Error(codes.Internal, "Failed to revoke token")
+	}
+	return &pb.RevocationResponse{}, nil
+}
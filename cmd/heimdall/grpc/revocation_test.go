@@ -0,0 +1,140 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/cmd/heimdall/grpc"
+	pb "github.com/thetkpark/heimdall/cmd/heimdall/proto"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"github.com/thetkpark/heimdall/test/mock_revocation"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+var _ = Describe("RevocationServer_gRPC", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockRevocations  *mock_revocation.MockStore
+		revocationServer *grpc.RevocationServer
+		callerCtx        context.Context
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockRevocations = mock_revocation.NewMockStore(mockCtrl)
+		registry, err := client.NewRegistry()
+		Expect(err).To(BeNil())
+		revocationServer = grpc.NewRevocationServer(zap.NewNop().Sugar(), mockRevocations, client.NewIssuerAuthenticator(registry, "admin-token"), time.Hour)
+		callerCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer admin-token"))
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("RevokeToken", func() {
+		var (
+			req      *pb.RevokeTokenRequest
+			resError error
+		)
+
+		BeforeEach(func() {
+			req = &pb.RevokeTokenRequest{ID: "token-id"}
+		})
+
+		JustBeforeEach(func() {
+			_, resError = revocationServer.RevokeToken(callerCtx, req)
+		})
+
+		When("Request is valid", func() {
+			BeforeEach(func() {
+				mockRevocations.EXPECT().RevokeToken(gomock.Any(), "token-id", gomock.Any()).Return(nil).Times(1)
+			})
+
+			It("should revoke the token", func() {
+				Expect(resError).To(BeNil())
+			})
+		})
+
+		When("Caller is not authenticated", func() {
+			BeforeEach(func() {
+				callerCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong-token"))
+			})
+
+			It("should return Unauthenticated error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("ID is empty", func() {
+			BeforeEach(func() {
+				req = &pb.RevokeTokenRequest{}
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("Failed to revoke token", func() {
+			BeforeEach(func() {
+				mockRevocations.EXPECT().RevokeToken(gomock.Any(), "token-id", gomock.Any()).Return(errors.New("some error")).Times(1)
+			})
+
+			It("should return Internal error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Internal))
+			})
+		})
+	})
+
+	Context("RevokeUserTokens", func() {
+		var (
+			req      *pb.RevokeUserTokensRequest
+			resError error
+		)
+
+		BeforeEach(func() {
+			req = &pb.RevokeUserTokensRequest{UserID: "99", IssuedBefore: 1660000000}
+		})
+
+		JustBeforeEach(func() {
+			_, resError = revocationServer.RevokeUserTokens(callerCtx, req)
+		})
+
+		When("Request is valid", func() {
+			BeforeEach(func() {
+				mockRevocations.EXPECT().RevokeSubject(gomock.Any(), "99", time.Unix(1660000000, 0)).Return(nil).Times(1)
+			})
+
+			It("should revoke the tokens", func() {
+				Expect(resError).To(BeNil())
+			})
+		})
+
+		When("Caller is not authenticated", func() {
+			BeforeEach(func() {
+				callerCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong-token"))
+			})
+
+			It("should return Unauthenticated error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("UserID is empty", func() {
+			BeforeEach(func() {
+				req = &pb.RevokeUserTokensRequest{}
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+})
@@ -1,52 +1,403 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"github.com/getsentry/sentry-go"
 	pb "github.com/thetkpark/heimdall/cmd/heimdall/proto"
+	"github.com/thetkpark/heimdall/pkg/client"
 	"github.com/thetkpark/heimdall/pkg/config"
 	"github.com/thetkpark/heimdall/pkg/token"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"regexp"
+	"strings"
 	"time"
 )
 
-func NewTokenServer(logger *zap.SugaredLogger, tokenMng token.Manager, validTime time.Duration) *TokenServer {
+// ErrorDomain is the domain of the ErrorInfo details of the errors.
+const ErrorDomain = "heimdall"
+
+var (
+	ScopeClaimConflictError = errors.New("scope claim cannot be set together with scopes")
+	BatchSizeError          = errors.New("batch size exceeds the limit")
+	TTLError                = errors.New("TTL exceeds the longest valid time of the tokens")
+	// CallerAuthenticationError is the error of the callers of the token generation and revocation without valid credentials
+	CallerAuthenticationError = errors.New("invalid caller credentials")
+)
+
+var tokenRegex = regexp.MustCompile(`^.+\..+\..+$`)
+
+var failureReasons = map[error]pb.TokenFailureReason{
+	token.TokenExpiredError:       pb.TokenFailureReason_TOKEN_EXPIRED,
+	token.TokenNotValidYetError:   pb.TokenFailureReason_TOKEN_NOT_VALID_YET,
+	token.TokenMissingExpiryError: pb.TokenFailureReason_TOKEN_MISSING_EXPIRY,
+	token.TokenIssuerError:        pb.TokenFailureReason_TOKEN_ISSUER_REJECTED,
+	token.TokenAudienceError:      pb.TokenFailureReason_TOKEN_AUDIENCE_REJECTED,
+	token.TokenRevokedError:       pb.TokenFailureReason_TOKEN_REVOKED,
+	token.TokenUseError:           pb.TokenFailureReason_TOKEN_USE_REJECTED,
+}
+
+// NewTokenServer returns the server of the tokens. Refresh tokens are disabled when refreshMng is nil.
+// The callers of the token generation are authenticated by issuers.
+// The tokens are valid for validTime unless the request has a TTL, which is limited to maxValidTime when it is not zero.
+// Batches are limited to maxBatchSize items, and their tokens are verified by batchWorkers goroutines.
+func NewTokenServer(logger *zap.SugaredLogger, tokenMng token.Manager, refreshMng token.RefreshManager, issuers *client.IssuerAuthenticator, validTime, maxValidTime time.Duration, maxBatchSize, batchWorkers int) *TokenServer {
 	return &TokenServer{
-		logger:       logger,
-		tokenManager: tokenMng,
-		validTime:    validTime,
+		logger:         logger,
+		tokenManager:   tokenMng,
+		refreshManager: refreshMng,
+		issuers:        issuers,
+		validTime:      validTime,
+		maxValidTime:   maxValidTime,
+		maxBatchSize:   maxBatchSize,
+		batchWorkers:   batchWorkers,
 	}
 }
 
 type TokenServer struct {
 	pb.UnimplementedTokenServer
-	logger       *zap.SugaredLogger
-	tokenManager token.Manager
-	validTime    time.Duration
+	logger         *zap.SugaredLogger
+	tokenManager   token.Manager
+	refreshManager token.RefreshManager
+	issuers        *client.IssuerAuthenticator
+	validTime      time.Duration
+	maxValidTime   time.Duration
+	maxBatchSize   int
+	batchWorkers   int
+}
+
+func (s TokenServer) GenerateToken(ctx context.Context, tokenReq *pb.GenerateTokenRequest) (*pb.TokenResponse, error) {
+	caller, err := authenticateCaller(ctx, s.issuers)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := s.newPayload(tokenReq, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := caller.Authorize(payload); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	res, err := s.generate(payload, caller.AllowsRefresh())
+	if err != nil {
+		return nil, s.generationError(err, payload)
+	}
+	return res, nil
 }
 
-func (s TokenServer) GenerateToken(_ context.Context, tokenReq *pb.GenerateTokenRequest) (*pb.TokenResponse, error) {
-	err := tokenReq.ValidateAll()
+// GenerateTokens reports the error of each request in its result, and only fails when the batch itself is invalid.
+func (s TokenServer) GenerateTokens(ctx context.Context, req *pb.GenerateTokensRequest) (*pb.GenerateTokensResponse, error) {
+	caller, err := authenticateCaller(ctx, s.issuers)
+	if err != nil {
+		return nil, err
+	}
+	err = req.ValidateAll()
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if len(req.GetRequests()) > s.maxBatchSize {
+		return nil, status.Error(codes.InvalidArgument, BatchSizeError.Error())
+	}
+
+	now := time.Now()
+	results := make([]*pb.GenerateTokenResult, len(req.GetRequests()))
+	payloads := make([]config.Payload, 0, len(req.GetRequests()))
+	indexes := make([]int, 0, len(req.GetRequests()))
+	for i, tokenReq := range req.GetRequests() {
+		payload, err := s.newPayload(tokenReq, now)
+		if err != nil {
+			results[i] = &pb.GenerateTokenResult{Error: err.Error()}
+			continue
+		}
+		if err := caller.Authorize(payload); err != nil {
+			results[i] = &pb.GenerateTokenResult{Error: err.Error()}
+			continue
+		}
+		payloads = append(payloads, payload)
+		indexes = append(indexes, i)
+	}
+
+	responses, errs := s.generateBatch(payloads, caller.AllowsRefresh())
+	for j, i := range indexes {
+		if errs[j] != nil {
+			results[i] = &pb.GenerateTokenResult{Error: status.Convert(s.generationError(errs[j], payloads[j])).Message()}
+			continue
+		}
+		results[i] = &pb.GenerateTokenResult{Token: responses[j]}
+	}
+	return &pb.GenerateTokensResponse{Results: results}, nil
+}
+
+// authenticateCaller authenticates the caller with the admin token or the API key in the authorization metadata,
+// or else with the verified TLS client certificate.
+func authenticateCaller(ctx context.Context, issuers *client.IssuerAuthenticator) (*client.Caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if authorization := md.Get("authorization"); len(authorization) > 0 {
+		if apiKey := strings.TrimPrefix(authorization[0], "Bearer "); apiKey != authorization[0] {
+			if caller, err := issuers.AuthenticateToken(apiKey); err == nil {
+				return caller, nil
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, CallerAuthenticationError.Error())
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+			if caller, err := issuers.AuthenticateCertificate(tlsInfo.State.VerifiedChains[0][0]); err == nil {
+				return caller, nil
+			}
+		}
+	}
+	return nil, status.Error(codes.Unauthenticated, CallerAuthenticationError.Error())
+}
+
+// newPayload validates the request and returns the payload of its claims, issued at now.
+func (s TokenServer) newPayload(tokenReq *pb.GenerateTokenRequest, now time.Time) (config.Payload, error) {
+	if err := tokenReq.ValidateAll(); err != nil {
+		return config.Payload{}, err
+	}
+	customPayload, err := customClaims(tokenReq)
+	if err != nil {
+		return config.Payload{}, err
+	}
+	if tokenReq.GetUserID() != 0 {
+		customPayload[token.UserIDClaim] = tokenReq.GetUserID()
+	}
+	if len(tokenReq.GetScopes()) > 0 {
+		if _, ok := customPayload[token.ScopeClaim]; ok {
+			return config.Payload{}, ScopeClaimConflictError
+		}
+		customPayload[token.ScopeClaim] = strings.Join(tokenReq.GetScopes(), " ")
+	}
+
 	payload := config.Payload{
-		CustomPayload: config.CustomPayload{UserID: tokenReq.GetUserID()},
+		CustomPayload: customPayload,
 		MetadataPayload: config.MetadataPayload{
-			IssuedAt:  time.Now().UTC(),
-			ExpiredAt: time.Now().Add(s.validTime).UTC(),
+			Audience: tokenReq.GetAudience(),
+			IssuedAt: config.NewNumericDate(now),
 		},
 	}
-	tokenString, err := s.tokenManager.Generate(payload)
+	if tokenReq.GetTTL() != nil {
+		ttl := tokenReq.GetTTL().AsDuration()
+		if s.maxValidTime > 0 && ttl > s.maxValidTime {
+			return config.Payload{}, TTLError
+		}
+		payload.ExpiredAt = config.NewNumericDate(now.Add(ttl))
+	} else if s.validTime > 0 {
+		payload.ExpiredAt = config.NewNumericDate(now.Add(s.validTime))
+	}
+	return payload, nil
+}
+
+// generationError returns the status of the error of generating the token of the payload.
+func (s TokenServer) generationError(err error, payload config.Payload) error {
+	var claimsErr *token.ClaimsError
+	if errors.As(err, &claimsErr) {
+		return status.Error(codes.InvalidArgument, claimsErr.Error())
+	}
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetExtra("payload", payload)
+		sentry.CaptureException(err)
+	})
+	s.logger.Errorw("s.tokenManager.Generate error", "error", err, "payload", payload)
+	return status.Error(codes.Internal, "Failed to generate token string")
+}
+
+func (s TokenServer) generateBatch(payloads []config.Payload, refresh bool) ([]*pb.TokenResponse, []error) {
+	responses := make([]*pb.TokenResponse, len(payloads))
+	if s.refreshManager == nil || !refresh {
+		tokens, errs := s.tokenManager.GenerateBatch(payloads)
+		for i, tokenString := range tokens {
+			if errs[i] == nil {
+				responses[i] = &pb.TokenResponse{Token: tokenString}
+			}
+		}
+		return responses, errs
+	}
+	pairs, errs := s.refreshManager.GenerateBatch(payloads)
+	for i, pair := range pairs {
+		if pair != nil {
+			responses[i] = &pb.TokenResponse{Token: pair.AccessToken, RefreshToken: pair.RefreshToken}
+		}
+	}
+	return responses, errs
+}
+
+func (s TokenServer) generate(payload config.Payload, refresh bool) (*pb.TokenResponse, error) {
+	if s.refreshManager == nil || !refresh {
+		tokenString, err := s.tokenManager.Generate(payload)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.TokenResponse{Token: tokenString}, nil
+	}
+	pair, err := s.refreshManager.Generate(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TokenResponse{Token: pair.AccessToken, RefreshToken: pair.RefreshToken}, nil
+}
+
+func (s TokenServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.TokenResponse, error) {
+	if s.refreshManager == nil {
+		return nil, status.Error(codes.Unimplemented, "Refresh tokens are disabled")
+	}
+	err := req.ValidateAll()
 	if err != nil {
-		sentry.WithScope(func(scope *sentry.Scope) {
-			scope.SetExtra("payload", payload)
-			sentry.CaptureException(err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pair, err := s.refreshManager.Refresh(ctx, req.GetRefreshToken())
+	if err != nil {
+		var validationErr *token.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, status.Error(codes.Unauthenticated, validationErr.Error())
+		}
+		return nil, status.Error(codes.Unauthenticated, "Failed to parse token")
+	}
+	return &pb.TokenResponse{Token: pair.AccessToken, RefreshToken: pair.RefreshToken}, nil
+}
+
+// customClaims decodes the custom claims as the REST API decodes them, e.g. the numbers as json.Number.
+func customClaims(tokenReq *pb.GenerateTokenRequest) (config.CustomPayload, error) {
+	if tokenReq.GetCustomClaims() == nil {
+		return config.CustomPayload{}, nil
+	}
+	rawClaims, err := protojson.Marshal(tokenReq.GetCustomClaims())
+	if err != nil {
+		return nil, err
+	}
+	return config.DecodeCustomPayload(bytes.NewReader(rawClaims))
+}
+
+// VerifyToken reports the failure reason in the response instead of returning an error.
+func (s TokenServer) VerifyToken(_ context.Context, req *pb.VerifyTokenRequest) (*pb.VerifyTokenResponse, error) {
+	err := req.ValidateAll()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	payload, reason, err := s.parse(req.GetToken())
+	if err != nil {
+		return &pb.VerifyTokenResponse{Reason: reason, Error: err.Error()}, nil
+	}
+	return &pb.VerifyTokenResponse{Valid: true, ExpiredAt: expiredAt(payload)}, nil
+}
+
+func (s TokenServer) ParseToken(_ context.Context, req *pb.ParseTokenRequest) (*pb.ParseTokenResponse, error) {
+	err := req.ValidateAll()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	payload, reason, err := s.parse(req.GetToken())
+	if err != nil {
+		st, detailsErr := status.New(codes.Unauthenticated, err.Error()).WithDetails(&errdetails.ErrorInfo{
+			Reason: reason.String(),
+			Domain: ErrorDomain,
 		})
-		s.logger.Errorw("s.tokenManager.Generate error", "error", err, "payload", payload)
-		return nil, status.Error(codes.Internal, "Failed to generate token string")
+		if detailsErr != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, st.Err()
+	}
+
+	claims, err := s.claims(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ParseTokenResponse{Claims: claims, ExpiredAt: expiredAt(payload)}, nil
+}
+
+// VerifyTokens reports the failure reason of each token in its result, and only fails when the batch itself is invalid.
+func (s TokenServer) VerifyTokens(_ context.Context, req *pb.VerifyTokensRequest) (*pb.VerifyTokensResponse, error) {
+	err := req.ValidateAll()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(req.GetTokens()) > s.maxBatchSize {
+		return nil, status.Error(codes.InvalidArgument, BatchSizeError.Error())
+	}
+
+	results := make([]*pb.VerifyTokenResult, len(req.GetTokens()))
+	tokens := make([]string, 0, len(req.GetTokens()))
+	indexes := make([]int, 0, len(req.GetTokens()))
+	for i, tokenString := range req.GetTokens() {
+		if !tokenRegex.MatchString(tokenString) {
+			results[i] = &pb.VerifyTokenResult{Reason: pb.TokenFailureReason_TOKEN_MALFORMED, Error: TokenFormatError.Error()}
+			continue
+		}
+		tokens = append(tokens, tokenString)
+		indexes = append(indexes, i)
+	}
+
+	payloads, errs := token.ParseBatch(s.tokenManager, tokens, s.batchWorkers)
+	for j, i := range indexes {
+		if errs[j] != nil {
+			reason, err := failure(errs[j])
+			results[i] = &pb.VerifyTokenResult{Reason: reason, Error: err.Error()}
+			continue
+		}
+		claims, err := s.claims(payloads[j])
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &pb.VerifyTokenResult{Valid: true, ExpiredAt: expiredAt(payloads[j]), Claims: claims}
+	}
+	return &pb.VerifyTokensResponse{Results: results}, nil
+}
+
+// claims returns the Struct of the registered and custom claims of the payload.
+func (s TokenServer) claims(payload *config.Payload) (*structpb.Struct, error) {
+	// Struct numbers are float64, so integers beyond 2^53 lose precision
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Errorw("json.Marshal error", "error", err)
+		return nil, status.Error(codes.Internal, "Failed to encode claims")
+	}
+	claims := &structpb.Struct{}
+	if err := protojson.Unmarshal(rawPayload, claims); err != nil {
+		s.logger.Errorw("protojson.Unmarshal error", "error", err)
+		return nil, status.Error(codes.Internal, "Failed to encode claims")
+	}
+	return claims, nil
+}
+
+// parse verifies the token as the REST API does, and returns the reason when it is rejected.
+func (s TokenServer) parse(tokenString string) (*config.Payload, pb.TokenFailureReason, error) {
+	if !tokenRegex.MatchString(tokenString) {
+		return nil, pb.TokenFailureReason_TOKEN_MALFORMED, TokenFormatError
+	}
+	payload, err := s.tokenManager.Parse(tokenString)
+	if err != nil {
+		reason, err := failure(err)
+		return nil, reason, err
+	}
+	return payload, pb.TokenFailureReason_TOKEN_FAILURE_REASON_UNSPECIFIED, nil
+}
+
+// failure returns the reason and the error of the error of parsing a token, as the REST API reports them.
+func failure(err error) (pb.TokenFailureReason, error) {
+	var validationErr *token.ValidationError
+	if errors.As(err, &validationErr) {
+		reason, ok := failureReasons[validationErr.Err]
+		if !ok {
+			reason = pb.TokenFailureReason_TOKEN_REJECTED
+		}
+		return reason, validationErr.Err
+	}
+	return pb.TokenFailureReason_TOKEN_MALFORMED, TokenParsingError
+}
+
+func expiredAt(payload *config.Payload) int64 {
+	if payload.ExpiredAt == nil {
+		return 0
 	}
-	return &pb.TokenResponse{Token: tokenString}, nil
+	return payload.ExpiredAt.Unix()
 }
@@ -2,29 +2,60 @@ package grpc_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/thetkpark/heimdall/cmd/heimdall/grpc"
 	pb "github.com/thetkpark/heimdall/cmd/heimdall/proto"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/token"
 	"github.com/thetkpark/heimdall/test/mock_token"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"time"
 )
 
+const apiKey = "api-key"
+
 var _ = Describe("TokenServer_gRPC", func() {
 
 	var (
 		mockCtrl         *gomock.Controller
 		mockTokenManager *mock_token.MockManager
+		mockRefreshMng   *mock_token.MockRefreshManager
 		tokenServer      *grpc.TokenServer
+		issuers          *client.IssuerAuthenticator
+		callerCtx        context.Context
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockTokenManager = mock_token.NewMockManager(mockCtrl)
-		tokenServer = grpc.NewTokenServer(zap.NewNop().Sugar(), mockTokenManager, time.Hour)
+		mockRefreshMng = mock_token.NewMockRefreshManager(mockCtrl)
+		apiKeyHash := sha256.Sum256([]byte(apiKey))
+		registry, err := client.NewRegistry(client.Client{ID: "backend", APIKeyHash: hex.EncodeToString(apiKeyHash[:]), Issuance: &client.IssuancePolicy{
+			Claims:  []string{"roles"},
+			UserIDs: []client.UserIDRange{{Min: 1, Max: 99}},
+		}})
+		Expect(err).To(BeNil())
+		issuers = client.NewIssuerAuthenticator(registry, "admin-token")
+		callerCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer admin-token"))
+		tokenServer = grpc.NewTokenServer(zap.NewNop().Sugar(), mockTokenManager, nil, issuers, time.Hour, 24*time.Hour, 2, 2)
 	})
 
 	AfterEach(func() {
@@ -45,7 +76,7 @@ var _ = Describe("TokenServer_gRPC", func() {
 		})
 
 		JustBeforeEach(func() {
-			res, resError = handler(context.Background(), req)
+			res, resError = handler(callerCtx, req)
 		})
 
 		When("Request is valid", func() {
@@ -59,6 +90,172 @@ var _ = Describe("TokenServer_gRPC", func() {
 			})
 		})
 
+		When("Request has claims", func() {
+			BeforeEach(func() {
+				req = &pb.GenerateTokenRequest{UserID: 99999, CustomClaims: mustNewStruct(map[string]interface{}{"roles": []interface{}{"admin"}})}
+				mockTokenManager.EXPECT().Generate(gomock.Any()).DoAndReturn(func(payload config.Payload) (string, error) {
+					Expect(payload.CustomPayload).To(Equal(config.CustomPayload{
+						"user_id": uint64(99999),
+						"roles":   []interface{}{"admin"},
+					}))
+					return "token", nil
+				}).Times(1)
+			})
+
+			It("should get the token successfully", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.Token).To(Equal("token"))
+			})
+		})
+
+		When("Request has custom claims, TTL, audience and scopes", func() {
+			BeforeEach(func() {
+				customClaims, err := structpb.NewStruct(map[string]interface{}{
+					"roles":  []interface{}{"admin"},
+					"tenant": map[string]interface{}{"id": "acme"},
+				})
+				Expect(err).To(BeNil())
+				req = &pb.GenerateTokenRequest{
+					UserID:       99999,
+					CustomClaims: customClaims,
+					TTL:          durationpb.New(time.Minute),
+					Audience:     []string{"api"},
+					Scopes:       []string{"read", "write"},
+				}
+				mockTokenManager.EXPECT().Generate(gomock.Any()).DoAndReturn(func(payload config.Payload) (string, error) {
+					Expect(payload.CustomPayload).To(Equal(config.CustomPayload{
+						"user_id": uint64(99999),
+						"roles":   []interface{}{"admin"},
+						"tenant":  map[string]interface{}{"id": "acme"},
+						"scope":   "read write",
+					}))
+					Expect(payload.Audience).To(Equal(config.Audience{"api"}))
+					Expect(payload.ExpiredAt.Time).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+					return "token", nil
+				}).Times(1)
+			})
+
+			It("should get the token successfully", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.Token).To(Equal("token"))
+			})
+		})
+
+		When("Scope claim is set together with scopes", func() {
+			BeforeEach(func() {
+				req = &pb.GenerateTokenRequest{UserID: 99999, CustomClaims: mustNewStruct(map[string]interface{}{"scope": "admin"}), Scopes: []string{"read"}}
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("TTL exceeds the longest valid time", func() {
+			BeforeEach(func() {
+				req = &pb.GenerateTokenRequest{UserID: 99999, TTL: durationpb.New(25 * time.Hour)}
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+				Expect(status.Convert(resError).Message()).To(Equal(grpc.TTLError.Error()))
+			})
+		})
+
+		for _, invalid := range []struct {
+			name string
+			req  *pb.GenerateTokenRequest
+		}{
+			{"TTL is not positive", &pb.GenerateTokenRequest{UserID: 99999, TTL: durationpb.New(0)}},
+			{"Audience is empty", &pb.GenerateTokenRequest{UserID: 99999, Audience: []string{""}}},
+			{"Scopes are duplicated", &pb.GenerateTokenRequest{UserID: 99999, Scopes: []string{"read", "read"}}},
+			{"Scope has space", &pb.GenerateTokenRequest{UserID: 99999, Scopes: []string{"read write"}}},
+		} {
+			invalidReq := invalid.req
+			When(invalid.name, func() {
+				BeforeEach(func() {
+					req = invalidReq
+				})
+
+				It("should return InvalidArgument error", func() {
+					Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+				})
+			})
+		}
+
+		When("Claims are rejected", func() {
+			BeforeEach(func() {
+				req = &pb.GenerateTokenRequest{CustomClaims: mustNewStruct(map[string]interface{}{"roles": []interface{}{"admin"}})}
+				mockTokenManager.EXPECT().Generate(gomock.Any()).Return("", &token.ClaimsError{Err: errors.New("missing properties: 'user_id'")}).Times(1)
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("Caller credentials are missing", func() {
+			BeforeEach(func() {
+				callerCtx = context.Background()
+			})
+
+			It("should return Unauthenticated error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Unauthenticated))
+				Expect(status.Convert(resError).Message()).To(Equal(grpc.CallerAuthenticationError.Error()))
+			})
+		})
+
+		When("Caller API key is invalid", func() {
+			BeforeEach(func() {
+				callerCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong"))
+			})
+
+			It("should return Unauthenticated error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("Client certificate is verified", func() {
+			BeforeEach(func() {
+				callerCtx = peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "service"}}}},
+				}}})
+				registry, err := client.NewRegistry(client.Client{ID: "service", CertificateSubject: "CN=service", Issuance: &client.IssuancePolicy{}})
+				Expect(err).To(BeNil())
+				tokenServer = grpc.NewTokenServer(zap.NewNop().Sugar(), mockTokenManager, nil, client.NewIssuerAuthenticator(registry, ""), time.Hour, 24*time.Hour, 2, 2)
+				handler = tokenServer.GenerateToken
+				mockTokenManager.EXPECT().Generate(gomock.Any()).Return("token", nil).Times(1)
+			})
+
+			It("should get the token", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.Token).To(Equal("token"))
+			})
+		})
+
+		When("Caller is not allowed to issue the user", func() {
+			BeforeEach(func() {
+				callerCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+apiKey))
+			})
+
+			It("should return PermissionDenied error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.PermissionDenied))
+				Expect(status.Convert(resError).Message()).To(Equal(client.UserIDForbiddenError.Error()))
+			})
+		})
+
+		When("Caller is not allowed to issue the audience", func() {
+			BeforeEach(func() {
+				callerCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+apiKey))
+				req = &pb.GenerateTokenRequest{UserID: 99, Audience: []string{"admin"}}
+			})
+
+			It("should return PermissionDenied error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.PermissionDenied))
+				Expect(status.Convert(resError).Message()).To(ContainSubstring(client.AudienceForbiddenError.Error()))
+			})
+		})
+
 		When("Failed to generate token", func() {
 			BeforeEach(func() {
 				mockTokenManager.EXPECT().Generate(gomock.Any()).Return("", errors.New("failed to generate")).Times(1)
@@ -69,5 +266,410 @@ var _ = Describe("TokenServer_gRPC", func() {
 			})
 		})
 
+		When("Refresh tokens are enabled", func() {
+			BeforeEach(func() {
+				tokenServer = grpc.NewTokenServer(zap.NewNop().Sugar(), mockTokenManager, mockRefreshMng, issuers, time.Hour, 24*time.Hour, 2, 2)
+				handler = tokenServer.GenerateToken
+				mockRefreshMng.EXPECT().Generate(gomock.Any()).Return(&token.TokenPair{AccessToken: "token", RefreshToken: "refresh"}, nil).Times(1)
+			})
+
+			It("should get the token and refresh token", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.Token).To(Equal("token"))
+				Expect(res.RefreshToken).To(Equal("refresh"))
+			})
+		})
+	})
+
+	Context("GenerateTokens", func() {
+		var (
+			req      *pb.GenerateTokensRequest
+			res      *pb.GenerateTokensResponse
+			resError error
+		)
+
+		BeforeEach(func() {
+			req = &pb.GenerateTokensRequest{Requests: []*pb.GenerateTokenRequest{{UserID: 1}, {UserID: 2}}}
+		})
+
+		JustBeforeEach(func() {
+			res, resError = tokenServer.GenerateTokens(callerCtx, req)
+		})
+
+		When("Requests are valid", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().GenerateBatch(gomock.Len(2)).Return([]string{"token1", "token2"}, []error{nil, nil}).Times(1)
+			})
+
+			It("should get the token of each request", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.GetResults()).To(HaveLen(2))
+				Expect(res.GetResults()[0].GetToken().GetToken()).To(Equal("token1"))
+				Expect(res.GetResults()[1].GetToken().GetToken()).To(Equal("token2"))
+			})
+		})
+
+		When("A request is invalid", func() {
+			BeforeEach(func() {
+				req.Requests[0].TTL = durationpb.New(0)
+				mockTokenManager.EXPECT().GenerateBatch(gomock.Len(1)).Return([]string{"token2"}, []error{nil}).Times(1)
+			})
+
+			It("should get the error of the invalid request only", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.GetResults()[0].GetToken()).To(BeNil())
+				Expect(res.GetResults()[0].GetError()).ToNot(BeEmpty())
+				Expect(res.GetResults()[1].GetToken().GetToken()).To(Equal("token2"))
+			})
+		})
+
+		When("A token has reserved claims", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().GenerateBatch(gomock.Len(2)).Return([]string{"", "token2"}, []error{&token.ClaimsError{Err: token.ReservedClaimError}, nil}).Times(1)
+			})
+
+			It("should get the error of the claims", func() {
+				Expect(res.GetResults()[0].GetError()).To(ContainSubstring(token.ReservedClaimError.Error()))
+				Expect(res.GetResults()[1].GetToken().GetToken()).To(Equal("token2"))
+			})
+		})
+
+		When("Caller is not allowed to issue a request", func() {
+			BeforeEach(func() {
+				callerCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+apiKey))
+				req.Requests[1].UserID = 100
+				mockTokenManager.EXPECT().GenerateBatch(gomock.Len(1)).Return([]string{"token1"}, []error{nil}).Times(1)
+			})
+
+			It("should get the error of the request", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.GetResults()[0].GetToken().GetToken()).To(Equal("token1"))
+				Expect(res.GetResults()[1].GetError()).To(Equal(client.UserIDForbiddenError.Error()))
+			})
+		})
+
+		When("Caller credentials are missing", func() {
+			BeforeEach(func() {
+				callerCtx = context.Background()
+			})
+
+			It("should return Unauthenticated error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Unauthenticated))
+			})
+		})
+
+		When("Requests are empty", func() {
+			BeforeEach(func() {
+				req = &pb.GenerateTokensRequest{}
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("Requests exceed the batch size", func() {
+			BeforeEach(func() {
+				req.Requests = append(req.Requests, &pb.GenerateTokenRequest{UserID: 3})
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+				Expect(status.Convert(resError).Message()).To(Equal(grpc.BatchSizeError.Error()))
+			})
+		})
+
+		When("Refresh tokens are enabled", func() {
+			BeforeEach(func() {
+				tokenServer = grpc.NewTokenServer(zap.NewNop().Sugar(), mockTokenManager, mockRefreshMng, issuers, time.Hour, 24*time.Hour, 2, 2)
+				mockRefreshMng.EXPECT().GenerateBatch(gomock.Len(2)).Return([]*token.TokenPair{
+					{AccessToken: "token1", RefreshToken: "refresh1"},
+					{AccessToken: "token2", RefreshToken: "refresh2"},
+				}, []error{nil, nil}).Times(1)
+			})
+
+			It("should get the pair of each request", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.GetResults()[1].GetToken().GetRefreshToken()).To(Equal("refresh2"))
+			})
+		})
+	})
+
+	Context("RefreshToken", func() {
+		var (
+			req      *pb.RefreshTokenRequest
+			res      *pb.TokenResponse
+			resError error
+		)
+
+		BeforeEach(func() {
+			tokenServer = grpc.NewTokenServer(zap.NewNop().Sugar(), mockTokenManager, mockRefreshMng, issuers, time.Hour, 24*time.Hour, 2, 2)
+			req = &pb.RefreshTokenRequest{RefreshToken: "refresh"}
+		})
+
+		JustBeforeEach(func() {
+			res, resError = tokenServer.RefreshToken(context.Background(), req)
+		})
+
+		When("Refresh token is valid", func() {
+			BeforeEach(func() {
+				mockRefreshMng.EXPECT().Refresh(gomock.Any(), "refresh").Return(&token.TokenPair{AccessToken: "new-token", RefreshToken: "new-refresh"}, nil).Times(1)
+			})
+
+			It("should get the new pair", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.Token).To(Equal("new-token"))
+				Expect(res.RefreshToken).To(Equal("new-refresh"))
+			})
+		})
+
+		When("Refresh token is reused", func() {
+			BeforeEach(func() {
+				mockRefreshMng.EXPECT().Refresh(gomock.Any(), "refresh").Return(nil, &token.ValidationError{Err: token.RefreshTokenReusedError}).Times(1)
+			})
+
+			It("should return Unauthenticated error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Unauthenticated))
+				Expect(status.Convert(resError).Message()).To(Equal(token.RefreshTokenReusedError.Error()))
+			})
+		})
+
+		When("Refresh token is missing", func() {
+			BeforeEach(func() {
+				req = &pb.RefreshTokenRequest{}
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("Refresh tokens are disabled", func() {
+			BeforeEach(func() {
+				tokenServer = grpc.NewTokenServer(zap.NewNop().Sugar(), mockTokenManager, nil, issuers, time.Hour, 24*time.Hour, 2, 2)
+			})
+
+			It("should return Unimplemented error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Unimplemented))
+			})
+		})
+	})
+
+	Context("VerifyToken", func() {
+		var (
+			req      *pb.VerifyTokenRequest
+			res      *pb.VerifyTokenResponse
+			resError error
+		)
+
+		BeforeEach(func() {
+			req = &pb.VerifyTokenRequest{Token: "valid.token.string"}
+		})
+
+		JustBeforeEach(func() {
+			res, resError = tokenServer.VerifyToken(context.Background(), req)
+		})
+
+		When("Token is valid", func() {
+			var expiredAt = time.Now().Add(time.Hour)
+
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(&config.Payload{
+					MetadataPayload: config.MetadataPayload{ExpiredAt: config.NewNumericDate(expiredAt)},
+				}, nil).Times(1)
+			})
+
+			It("should return valid with the expiry", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.Valid).To(BeTrue())
+				Expect(res.ExpiredAt).To(Equal(expiredAt.Unix()))
+			})
+		})
+
+		When("Token is revoked", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(nil, &token.ValidationError{Err: token.TokenRevokedError}).Times(1)
+			})
+
+			It("should return the reason", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.Valid).To(BeFalse())
+				Expect(res.Reason).To(Equal(pb.TokenFailureReason_TOKEN_REVOKED))
+				Expect(res.Error).To(Equal(token.TokenRevokedError.Error()))
+			})
+		})
+
+		When("Token is rejected by a custom validator", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(nil, &token.ValidationError{Err: errors.New("user is banned")}).Times(1)
+			})
+
+			It("should return TOKEN_REJECTED", func() {
+				Expect(res.Reason).To(Equal(pb.TokenFailureReason_TOKEN_REJECTED))
+				Expect(res.Error).To(Equal("user is banned"))
+			})
+		})
+
+		When("Token is malformed", func() {
+			BeforeEach(func() {
+				req = &pb.VerifyTokenRequest{Token: "malformed"}
+			})
+
+			It("should return TOKEN_MALFORMED", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.Valid).To(BeFalse())
+				Expect(res.Reason).To(Equal(pb.TokenFailureReason_TOKEN_MALFORMED))
+			})
+		})
+
+		When("Token is missing", func() {
+			BeforeEach(func() {
+				req = &pb.VerifyTokenRequest{}
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("VerifyTokens", func() {
+		var (
+			req      *pb.VerifyTokensRequest
+			res      *pb.VerifyTokensResponse
+			resError error
+		)
+
+		BeforeEach(func() {
+			req = &pb.VerifyTokensRequest{Tokens: []string{"valid.token.string", "expired.token.string"}}
+		})
+
+		JustBeforeEach(func() {
+			res, resError = tokenServer.VerifyTokens(context.Background(), req)
+		})
+
+		When("Tokens are verified", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(&config.Payload{
+					CustomPayload:   config.CustomPayload{"user_id": json.Number("99")},
+					MetadataPayload: config.MetadataPayload{ExpiredAt: config.NewNumericDate(time.Unix(1700000000, 0))},
+				}, nil).Times(1)
+				mockTokenManager.EXPECT().Parse("expired.token.string").Return(nil, &token.ValidationError{Err: token.TokenExpiredError}).Times(1)
+			})
+
+			It("should get the result of each token", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.GetResults()).To(HaveLen(2))
+				Expect(res.GetResults()[0].GetValid()).To(BeTrue())
+				Expect(res.GetResults()[0].GetExpiredAt()).To(Equal(int64(1700000000)))
+				Expect(res.GetResults()[0].GetClaims().GetFields()["user_id"].GetNumberValue()).To(Equal(float64(99)))
+				Expect(res.GetResults()[1].GetValid()).To(BeFalse())
+				Expect(res.GetResults()[1].GetReason()).To(Equal(pb.TokenFailureReason_TOKEN_EXPIRED))
+				Expect(res.GetResults()[1].GetError()).To(Equal(token.TokenExpiredError.Error()))
+			})
+		})
+
+		When("Token is malformed", func() {
+			BeforeEach(func() {
+				req.Tokens = []string{"malformed"}
+			})
+
+			It("should get the malformed reason without parsing it", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.GetResults()[0].GetReason()).To(Equal(pb.TokenFailureReason_TOKEN_MALFORMED))
+			})
+		})
+
+		When("Tokens are empty", func() {
+			BeforeEach(func() {
+				req = &pb.VerifyTokensRequest{}
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("Tokens exceed the batch size", func() {
+			BeforeEach(func() {
+				req.Tokens = append(req.Tokens, "third.token.string")
+			})
+
+			It("should return InvalidArgument error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("ParseToken", func() {
+		var (
+			req      *pb.ParseTokenRequest
+			res      *pb.ParseTokenResponse
+			resError error
+		)
+
+		BeforeEach(func() {
+			req = &pb.ParseTokenRequest{Token: "valid.token.string"}
+		})
+
+		JustBeforeEach(func() {
+			res, resError = tokenServer.ParseToken(context.Background(), req)
+		})
+
+		When("Token is valid", func() {
+			var expiredAt = time.Now().Add(time.Hour)
+
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(&config.Payload{
+					CustomPayload:   config.CustomPayload{"user_id": json.Number("99"), "roles": []interface{}{"admin"}},
+					MetadataPayload: config.MetadataPayload{Subject: "99", ExpiredAt: config.NewNumericDate(expiredAt)},
+				}, nil).Times(1)
+			})
+
+			It("should return the claims", func() {
+				Expect(resError).To(BeNil())
+				Expect(res.ExpiredAt).To(Equal(expiredAt.Unix()))
+				Expect(res.Claims.AsMap()).To(Equal(map[string]interface{}{
+					"user_id": float64(99),
+					"roles":   []interface{}{"admin"},
+					"sub":     "99",
+					"exp":     float64(expiredAt.Unix()),
+				}))
+			})
+		})
+
+		When("Token is expired", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(nil, &token.ValidationError{Err: token.TokenExpiredError}).Times(1)
+			})
+
+			It("should return Unauthenticated error with the reason", func() {
+				st := status.Convert(resError)
+				Expect(st.Code()).To(Equal(codes.Unauthenticated))
+				Expect(st.Message()).To(Equal(token.TokenExpiredError.Error()))
+				Expect(st.Details()).To(HaveLen(1))
+				errorInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+				Expect(ok).To(BeTrue())
+				Expect(errorInfo.Reason).To(Equal("TOKEN_EXPIRED"))
+				Expect(errorInfo.Domain).To(Equal(grpc.ErrorDomain))
+			})
+		})
+
+		When("Token is invalid", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(nil, errors.New("invalid signature")).Times(1)
+			})
+
+			It("should return Unauthenticated error", func() {
+				Expect(status.Code(resError)).To(Equal(codes.Unauthenticated))
+				Expect(status.Convert(resError).Message()).To(Equal(grpc.TokenParsingError.Error()))
+			})
+		})
 	})
 })
+
+func mustNewStruct(fields map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(fields)
+	Expect(err).To(BeNil())
+	return s
+}
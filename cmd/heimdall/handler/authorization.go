@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	sentrygin "github.com/getsentry/sentry-go/gin"
+	"github.com/gin-gonic/gin"
+	"github.com/thetkpark/heimdall/pkg/authz"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	AuthorizationError           = errors.New("failed to authorize request")
+	UnknownForwardedHeadersError = errors.New("unknown forwarded headers")
+)
+
+// ForwardedHeaders are the headers of the original request that are read, which must be the ones that the reverse proxy sets,
+// as the clients may send the headers of the other proxies themselves, e.g. X-Forwarded-Uri through nginx.
+type ForwardedHeaders string
+
+const (
+	// TraefikForwardedHeaders are X-Forwarded-Method, X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Uri, which Traefik and Caddy set.
+	TraefikForwardedHeaders ForwardedHeaders = "traefik"
+	// NginxForwardedHeaders are X-Original-Method, and X-Original-URI or X-Original-URL, as nginx auth_request is configured.
+	NginxForwardedHeaders ForwardedHeaders = "nginx"
+)
+
+func ParseForwardedHeaders(proxy string) (ForwardedHeaders, error) {
+	switch ForwardedHeaders(proxy) {
+	case TraefikForwardedHeaders, NginxForwardedHeaders:
+		return ForwardedHeaders(proxy), nil
+	}
+	return "", UnknownForwardedHeadersError
+}
+
+// method returns the method of the original request.
+func (f ForwardedHeaders) method(c *gin.Context) string {
+	if f == NginxForwardedHeaders {
+		return c.GetHeader("X-Original-Method")
+	}
+	return c.GetHeader("X-Forwarded-Method")
+}
+
+// uri returns the URI of the original request.
+func (f ForwardedHeaders) uri(c *gin.Context) string {
+	if f != NginxForwardedHeaders {
+		return c.GetHeader("X-Forwarded-Uri")
+	}
+	if uri := c.GetHeader("X-Original-URI"); len(uri) > 0 {
+		return uri
+	}
+	if original, err := url.Parse(c.GetHeader("X-Original-URL")); err == nil && len(original.Host) > 0 {
+		return original.RequestURI()
+	}
+	return ""
+}
+
+type AuthorizationHandler struct {
+	logger           *zap.SugaredLogger
+	policy           *authz.Policy
+	forwardedHeaders ForwardedHeaders
+}
+
+// NewAuthorizationHandler returns the handler that authorizes the requests forwarded with forwardedHeaders with the rules of the policy.
+func NewAuthorizationHandler(logger *zap.SugaredLogger, policy *authz.Policy, forwardedHeaders ForwardedHeaders) *AuthorizationHandler {
+	return &AuthorizationHandler{
+		logger:           logger,
+		policy:           policy,
+		forwardedHeaders: forwardedHeaders,
+	}
+}
+
+// AuthorizeRequest authorizes the payload set by AuthenticateToken for the first rule that matches the forwarded request.
+// Requests that match no rule are authorized, and the payloads without the scopes or the roles of the rule,
+// or that do not satisfy its condition, are forbidden. Requests without the forwarded URI are forbidden when the policy has rules.
+func (h AuthorizationHandler) AuthorizeRequest(c *gin.Context) {
+	headers := make(map[string]string, len(c.Request.Header))
+	for name := range c.Request.Header {
+		headers[strings.ToLower(name)] = c.Request.Header.Get(name)
+	}
+	req := authz.NewRequest(h.forwardedHeaders.method(c), h.forwardedHeaders.uri(c), headers, c.ClientIP())
+	rule, err := h.policy.Match(req)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusForbidden, err)
+		return
+	}
+	if rule == nil {
+		c.Next()
+		return
+	}
+
+	payloadValue, _ := c.Get("payload")
+	payload, ok := payloadValue.(*config.Payload)
+	if !ok {
+		h.logger.Error("Failed get payload from context")
+		_ = c.AbortWithError(http.StatusInternalServerError, GetPayloadFromContextError)
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(GetPayloadFromContextError)
+		}
+		return
+	}
+	if err := rule.Authorize(req, payload); err != nil {
+		switch {
+		case errors.Is(err, authz.InsufficientScopeError):
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(rule.Scopes, " ")))
+		case errors.Is(err, authz.ConditionFailedError):
+			// The evaluation errors are only logged, as they reveal the condition
+			if err != authz.ConditionFailedError {
+				h.logger.Warnw("Failed to evaluate policy condition", "error", err, "condition", rule.Condition)
+			}
+			err = authz.ConditionFailedError
+		case !errors.Is(err, authz.InsufficientRoleError):
+			h.logger.Errorw("rule.Authorize error", "error", err)
+			_ = c.AbortWithError(http.StatusInternalServerError, AuthorizationError)
+			if hub := sentrygin.GetHubFromContext(c); hub != nil {
+				hub.CaptureException(err)
+			}
+			return
+		}
+		_ = c.AbortWithError(http.StatusForbidden, err)
+		return
+	}
+	c.Next()
+}
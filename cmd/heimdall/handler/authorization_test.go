@@ -0,0 +1,169 @@
+package handler_test
+
+import (
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/cmd/heimdall/handler"
+	"github.com/thetkpark/heimdall/pkg/authz"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("AuthorizationHandler", func() {
+	var (
+		forwardedHeaders handler.ForwardedHeaders
+		rec              *httptest.ResponseRecorder
+		req              *http.Request
+		payload          *config.Payload
+	)
+
+	BeforeEach(func() {
+		forwardedHeaders = handler.TraefikForwardedHeaders
+		payload = &config.Payload{CustomPayload: config.CustomPayload{"scope": "invoices:read", "roles": []interface{}{"admin"}}}
+		rec = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodGet, "/auth/header", nil)
+	})
+
+	JustBeforeEach(func() {
+		policy, err := authz.NewPolicy(
+			authz.Rule{Methods: []string{"POST"}, Path: "/invoices/**", Scopes: []string{"invoices:write"}},
+			authz.Rule{Path: "/admin/**", Roles: []string{"admin"}},
+			authz.Rule{Path: "/tenants/*/**", Condition: `claims.tenant == request.path.split('/')[2] && request.headers['x-api-version'] == '2'`},
+		)
+		Expect(err).To(BeNil())
+		h := handler.NewAuthorizationHandler(zap.NewNop().Sugar(), policy, forwardedHeaders)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(handler.HTTPErrorHandler)
+		router.GET("/auth/header", func(c *gin.Context) {
+			c.Set("payload", payload)
+		}, h.AuthorizeRequest, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		router.ServeHTTP(rec, req)
+	})
+
+	When("Payload has the roles of the rule", func() {
+		BeforeEach(func() {
+			req.Header.Set("X-Forwarded-Uri", "/admin/users?page=2")
+		})
+
+		It("should authorize the request", func() {
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("Payload does not have the scopes of the rule", func() {
+		BeforeEach(func() {
+			req.Header.Set("X-Forwarded-Method", "POST")
+			req.Header.Set("X-Forwarded-Uri", "/invoices/42")
+		})
+
+		It("should return 403 with the required scopes", func() {
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+			Expect(rec.Body.String()).To(MatchJSON(`{"error": "insufficient scope"}`))
+			Expect(rec.Header().Get("WWW-Authenticate")).To(Equal(`Bearer error="insufficient_scope", scope="invoices:write"`))
+		})
+	})
+
+	When("Payload does not have the roles of the rule", func() {
+		BeforeEach(func() {
+			payload.CustomPayload["roles"] = "viewer"
+			req.Header.Set("X-Forwarded-Uri", "/admin")
+		})
+
+		It("should return 403", func() {
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+			Expect(rec.Body.String()).To(MatchJSON(`{"error": "insufficient role"}`))
+		})
+	})
+
+	When("Payload satisfies the condition of the rule", func() {
+		BeforeEach(func() {
+			payload.CustomPayload["tenant"] = "acme"
+			req.Header.Set("X-Api-Version", "2")
+			req.Header.Set("X-Forwarded-Uri", "/tenants/acme/invoices")
+		})
+
+		It("should authorize the request", func() {
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("Payload cannot be evaluated by the condition of the rule", func() {
+		BeforeEach(func() {
+			req.Header.Set("X-Api-Version", "2")
+			req.Header.Set("X-Forwarded-Uri", "/tenants/acme/invoices")
+		})
+
+		It("should return 403 without the evaluation error", func() {
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+			Expect(rec.Body.String()).To(MatchJSON(`{"error": "policy condition is not satisfied"}`))
+		})
+	})
+
+	When("Request matches no rule", func() {
+		BeforeEach(func() {
+			payload.CustomPayload = config.CustomPayload{}
+			req.Header.Set("X-Forwarded-Method", "GET")
+			req.Header.Set("X-Forwarded-Uri", "/invoices/42")
+		})
+
+		It("should authorize the request", func() {
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("Headers of nginx are forwarded", func() {
+		BeforeEach(func() {
+			forwardedHeaders = handler.NginxForwardedHeaders
+			payload.CustomPayload["roles"] = "viewer"
+			req.Header.Set("X-Original-Method", "GET")
+			req.Header.Set("X-Original-URI", "/admin/users")
+		})
+
+		It("should authorize the original URI", func() {
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+			Expect(rec.Body.String()).To(MatchJSON(`{"error": "insufficient role"}`))
+		})
+
+		When("Client sends the headers of Traefik", func() {
+			BeforeEach(func() {
+				req.Header.Set("X-Forwarded-Method", "GET")
+				req.Header.Set("X-Forwarded-Uri", "/invoices/42")
+			})
+
+			It("should not override the original URI", func() {
+				Expect(rec.Code).To(Equal(http.StatusForbidden))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "insufficient role"}`))
+			})
+		})
+
+		When("Original URL is forwarded", func() {
+			BeforeEach(func() {
+				req.Header.Del("X-Original-URI")
+				req.Header.Set("X-Original-URL", "https://app.example.com/admin/users")
+			})
+
+			It("should authorize its URI", func() {
+				Expect(rec.Code).To(Equal(http.StatusForbidden))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "insufficient role"}`))
+			})
+		})
+	})
+
+	When("Forwarded URI is missing", func() {
+		It("should return 403", func() {
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+			Expect(rec.Body.String()).To(MatchJSON(`{"error": "missing request path"}`))
+		})
+	})
+	It("should reject unknown forwarded headers", func() {
+		_, err := handler.ParseForwardedHeaders("apache")
+		Expect(err).To(Equal(handler.UnknownForwardedHeadersError))
+	})
+})
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DiscoveryDisabledError is returned when TOKEN_ISSUER is not an HTTP URL, as the endpoints would otherwise come from
+// the Host header of the request, which anyone could set in the cached document.
+var DiscoveryDisabledError = errors.New("discovery requires an HTTP URL issuer")
+
+// clientAuthMethods are the client authentication methods of OAuthHandler.AuthenticateClient.
+var clientAuthMethods = []string{"client_secret_basic", "client_secret_post"}
+
+// DiscoveryDocument is the OpenID Connect discovery document, which is also the RFC 8414 authorization server metadata.
+type DiscoveryDocument struct {
+	Issuer                                    string   `json:"issuer"`
+	JWKSURI                                   string   `json:"jwks_uri"`
+	TokenEndpoint                             string   `json:"token_endpoint"`
+	IntrospectionEndpoint                     string   `json:"introspection_endpoint"`
+	RevocationEndpoint                        string   `json:"revocation_endpoint"`
+	GrantTypesSupported                       []string `json:"grant_types_supported"`
+	ResponseTypesSupported                    []string `json:"response_types_supported"`
+	SubjectTypesSupported                     []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported          []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported         []string `json:"token_endpoint_auth_methods_supported"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported"`
+	RevocationEndpointAuthMethodsSupported    []string `json:"revocation_endpoint_auth_methods_supported"`
+	ScopesSupported                           []string `json:"scopes_supported,omitempty"`
+}
+
+type DiscoveryHandler struct {
+	issuer         string
+	keySetProvider signature.KeySetProvider
+	clients        *client.Registry
+	cacheMaxAge    time.Duration
+}
+
+// NewDiscoveryHandler returns the handler of the discovery document of the issuer, whose endpoints are under the issuer.
+func NewDiscoveryHandler(issuer string, keySetProvider signature.KeySetProvider, clients *client.Registry, cacheMaxAge time.Duration) *DiscoveryHandler {
+	return &DiscoveryHandler{
+		issuer:         issuer,
+		keySetProvider: keySetProvider,
+		clients:        clients,
+		cacheMaxAge:    cacheMaxAge,
+	}
+}
+
+// GetDiscoveryDocument godoc
+// @Summary      Get the OpenID Connect discovery document
+// @Description  The issuer, the endpoints and the capabilities of this instance. It is also served as the RFC 8414 authorization server metadata.
+// @Tags         key
+// @Produce      json
+// @Success      200  {object}  DiscoveryDocument
+// @Failure      404  {object}  ErrorResponse
+// @Router       /.well-known/openid-configuration [GET]
+func (h DiscoveryHandler) GetDiscoveryDocument(c *gin.Context) {
+	baseURL, ok := h.baseURL()
+	if !ok {
+		_ = c.AbortWithError(http.StatusNotFound, DiscoveryDisabledError)
+		return
+	}
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.cacheMaxAge.Seconds())))
+	c.JSON(http.StatusOK, DiscoveryDocument{
+		Issuer:                            h.issuer,
+		JWKSURI:                           baseURL + "/.well-known/jwks.json",
+		TokenEndpoint:                     baseURL + "/oauth/token",
+		IntrospectionEndpoint:             baseURL + "/introspect",
+		RevocationEndpoint:                baseURL + "/revoke",
+		GrantTypesSupported:               []string{GrantTypeClientCredentials, GrantTypeTokenExchange},
+		ResponseTypesSupported:            []string{},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  h.keySetProvider.Algorithms(),
+		TokenEndpointAuthMethodsSupported: clientAuthMethods,
+		IntrospectionEndpointAuthMethodsSupported: clientAuthMethods,
+		RevocationEndpointAuthMethodsSupported:    clientAuthMethods,
+		ScopesSupported:                           h.clients.Scopes(),
+	})
+}
+
+// baseURL returns the issuer without the trailing slash, and whether it is an HTTP URL.
+func (h DiscoveryHandler) baseURL() (string, bool) {
+	issuerURL, err := url.Parse(h.issuer)
+	if err != nil || (issuerURL.Scheme != "https" && issuerURL.Scheme != "http") || len(issuerURL.Host) == 0 {
+		return "", false
+	}
+	return strings.TrimSuffix(h.issuer, "/"), true
+}
@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/cmd/heimdall/handler"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("DiscoveryHandler", func() {
+	var (
+		c        *gin.Context
+		rec      *httptest.ResponseRecorder
+		issuer   string
+		keyring  *signature.Keyring
+		clients  *client.Registry
+		document handler.DiscoveryDocument
+	)
+
+	BeforeEach(func() {
+		secret, err := jwk.FromRaw([]byte("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"))
+		Expect(err).To(BeNil())
+		keyring, err = signature.NewKeyring(signature.Key{ID: "default", Algorithm: jwa.HS256, Key: secret})
+		Expect(err).To(BeNil())
+		clients, err = client.NewRegistry(
+			client.Client{ID: "billing", Scopes: []string{"write", "read"}},
+			client.Client{ID: "ledger", Scopes: []string{"read"}},
+		)
+		Expect(err).To(BeNil())
+		issuer = "https://auth.example.com/"
+		rec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(rec)
+		c.Request, _ = http.NewRequest(http.MethodGet, "http://heimdall:8080/.well-known/openid-configuration", nil)
+	})
+
+	JustBeforeEach(func() {
+		handler.NewDiscoveryHandler(issuer, keyring, clients, 15*time.Minute).GetDiscoveryDocument(c)
+		document = handler.DiscoveryDocument{}
+		if rec.Code == http.StatusOK {
+			Expect(json.Unmarshal(rec.Body.Bytes(), &document)).To(Succeed())
+		}
+	})
+
+	It("should return the endpoints under the issuer", func() {
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(document.Issuer).To(Equal("https://auth.example.com/"))
+		Expect(document.JWKSURI).To(Equal("https://auth.example.com/.well-known/jwks.json"))
+		Expect(document.TokenEndpoint).To(Equal("https://auth.example.com/oauth/token"))
+		Expect(document.IntrospectionEndpoint).To(Equal("https://auth.example.com/introspect"))
+		Expect(document.RevocationEndpoint).To(Equal("https://auth.example.com/revoke"))
+		Expect(rec.Header().Get("Cache-Control")).To(Equal("public, max-age=900"))
+	})
+
+	It("should return the capabilities of the instance", func() {
+		Expect(document.GrantTypesSupported).To(Equal([]string{"client_credentials", "urn:ietf:params:oauth:grant-type:token-exchange"}))
+		Expect(document.IDTokenSigningAlgValuesSupported).To(Equal([]string{"HS256"}))
+		Expect(document.ScopesSupported).To(Equal([]string{"read", "write"}))
+		Expect(document.TokenEndpointAuthMethodsSupported).To(ContainElements("client_secret_basic", "client_secret_post"))
+	})
+
+	When("Issuer is not a URL", func() {
+		BeforeEach(func() {
+			issuer = "heimdall"
+			c.Request.Header.Set("X-Forwarded-Proto", "https")
+		})
+
+		It("should return 404", func() {
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+			Expect(c.Errors.Last().Err).To(Equal(handler.DiscoveryDisabledError))
+			Expect(rec.Header().Get("Cache-Control")).To(BeEmpty())
+		})
+	})
+
+	When("Issuer is not set", func() {
+		BeforeEach(func() {
+			issuer = ""
+			c.Request.Host = "attacker.example.com"
+		})
+
+		It("should not return the host of the request", func() {
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+			Expect(rec.Body.String()).NotTo(ContainSubstring("attacker.example.com"))
+		})
+	})
+})
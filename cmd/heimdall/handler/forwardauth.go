@@ -0,0 +1,197 @@
+package handler
+
+import (
+	sentrygin "github.com/getsentry/sentry-go/gin"
+	"github.com/gin-gonic/gin"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/header"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ReturnToParameter is the query parameter of the login URL with the URL of the original request.
+const ReturnToParameter = "return_to"
+
+// forwardedRequest is the original request that the reverse proxy asks to authenticate.
+type forwardedRequest struct {
+	method string
+	proto  string
+	host   string
+	uri    string
+	// nginx is set when the request is forwarded by nginx, which cannot return redirects from auth_request
+	nginx bool
+}
+
+// request returns the original request. Traefik and Caddy also set X-Forwarded-Proto and X-Forwarded-Host,
+// while nginx only knows the scheme and the host from X-Original-URL.
+func (f ForwardedHeaders) request(c *gin.Context) forwardedRequest {
+	req := forwardedRequest{
+		method: f.method(c),
+		uri:    f.uri(c),
+	}
+	if f == NginxForwardedHeaders {
+		req.nginx = true
+		if original, err := url.Parse(c.GetHeader("X-Original-URL")); err == nil && len(original.Host) > 0 {
+			req.proto = original.Scheme
+			req.host = original.Host
+		}
+		return req
+	}
+	req.proto = c.GetHeader("X-Forwarded-Proto")
+	req.host = c.GetHeader("X-Forwarded-Host")
+	// Proxies may send a comma separated list when they are chained
+	if i := strings.IndexByte(req.proto, ','); i >= 0 {
+		req.proto = strings.TrimSpace(req.proto[:i])
+	}
+	return req
+}
+
+// url returns the URL of the original request, which is only the URI when the host is unknown.
+func (r forwardedRequest) url() string {
+	if len(r.host) == 0 {
+		return r.uri
+	}
+	proto := r.proto
+	if len(proto) == 0 {
+		proto = "http"
+	}
+	return proto + "://" + r.host + r.uri
+}
+
+type ForwardAuthHandler struct {
+	logger           *zap.SugaredLogger
+	tokenManager     token.Manager
+	loginURL         *url.URL
+	responseHeaders  []string
+	headerMapper     *header.Mapper
+	extractor        *token.Extractor
+	forwardedHeaders ForwardedHeaders
+}
+
+// NewForwardAuthHandler returns the handler of the forward authentication of nginx, Traefik and Caddy.
+// Browsers without a valid token are redirected to loginURL unless it is nil, and only the claim headers
+// in responseHeaders, or every claim header of headerMapper when it is empty, are returned.
+// The tokens are read by extractor from the headers of the forwarded request, and from its query for the proxies that forward the URI.
+// The original request is read from forwardedHeaders.
+func NewForwardAuthHandler(logger *zap.SugaredLogger, tokenMng token.Manager, loginURL *url.URL, responseHeaders []string, headerMapper *header.Mapper, extractor *token.Extractor, forwardedHeaders ForwardedHeaders) *ForwardAuthHandler {
+	canonicalHeaders := make([]string, 0, len(responseHeaders))
+	for _, name := range responseHeaders {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			canonicalHeaders = append(canonicalHeaders, http.CanonicalHeaderKey(name))
+		}
+	}
+	return &ForwardAuthHandler{
+		logger:           logger,
+		tokenManager:     tokenMng,
+		loginURL:         loginURL,
+		responseHeaders:  canonicalHeaders,
+		headerMapper:     headerMapper,
+		extractor:        extractor,
+		forwardedHeaders: forwardedHeaders,
+	}
+}
+
+// AuthenticateRequest sets the payload of the token of the forwarded request. Browsers without a valid token
+// are redirected to the login URL with the original URL in the return_to parameter, which is a 401 with a Location
+// header for nginx, as auth_request only accepts 2xx, 401 and 403 responses.
+func (h ForwardAuthHandler) AuthenticateRequest(c *gin.Context) {
+	req := h.forwardedHeaders.request(c)
+	payload, err := parseRequestToken(h.tokenManager, h.extractor, c.Request.Header, req.query())
+	if err == nil {
+		c.Set("payload", payload)
+		c.Next()
+		return
+	}
+
+	if !h.shouldRedirect(c, req) {
+		_ = c.AbortWithError(http.StatusUnauthorized, err)
+		return
+	}
+	c.Header("Location", h.loginLocation(req))
+	if req.nginx {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	c.AbortWithStatus(http.StatusFound)
+}
+
+// query returns the query of the original request, which is only known when the proxy forwards the URI.
+func (r forwardedRequest) query() url.Values {
+	original, err := url.ParseRequestURI(r.uri)
+	if err != nil {
+		return nil
+	}
+	return original.Query()
+}
+
+// shouldRedirect reports whether the original request is a navigation of a browser that can be redirected.
+func (h ForwardAuthHandler) shouldRedirect(c *gin.Context, req forwardedRequest) bool {
+	if h.loginURL == nil {
+		return false
+	}
+	switch strings.ToUpper(req.method) {
+	case "", http.MethodGet, http.MethodHead:
+	default:
+		return false
+	}
+	return strings.Contains(c.GetHeader("Accept"), "text/html")
+}
+
+func (h ForwardAuthHandler) loginLocation(req forwardedRequest) string {
+	location := *h.loginURL
+	query := location.Query()
+	query.Set(ReturnToParameter, req.url())
+	location.RawQuery = query.Encode()
+	return location.String()
+}
+
+// ForwardAuth godoc
+// @Summary      Authenticate the request forwarded by nginx, Traefik or Caddy
+// @Description  Accepts any method. The claims are set to the headers of /auth/header, limited to FORWARD_AUTH_RESPONSE_HEADERS when set.
+// @Description  Browsers without a valid token are redirected to FORWARD_AUTH_LOGIN_URL when set.
+// @Tags         token
+// @Security	 JWSToken
+// @Success      200
+// @Param X-Forwarded-Method header string false "Method of the original request, or X-Original-Method with FORWARDED_HEADERS=nginx"
+// @Param X-Forwarded-Proto header string false "Scheme of the original request"
+// @Param X-Forwarded-Host header string false "Host of the original request"
+// @Param X-Forwarded-Uri header string false "URI of the original request, or X-Original-URI with FORWARDED_HEADERS=nginx"
+// @Param X-Original-URL header string false "URL of the original request with FORWARDED_HEADERS=nginx"
+// @Failure      302
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /auth/forward [GET]
+// @Router       /auth/forward [HEAD]
+func (h ForwardAuthHandler) ForwardAuth(c *gin.Context) {
+	payloadValue, _ := c.Get("payload")
+	payload, ok := payloadValue.(*config.Payload)
+	if !ok {
+		h.logger.Error("Failed get payload from context")
+		_ = c.AbortWithError(http.StatusInternalServerError, GetPayloadFromContextError)
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(GetPayloadFromContextError)
+		}
+		return
+	}
+
+	headers, err := h.headerMapper.Headers(payload)
+	if err != nil {
+		h.logger.Errorw("Failed to format claim headers", "error", err)
+		_ = c.AbortWithError(http.StatusInternalServerError, ClaimHeaderError)
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(err)
+		}
+		return
+	}
+	for name, value := range headers {
+		if len(h.responseHeaders) > 0 && !containsString(h.responseHeaders, http.CanonicalHeaderKey(name)) {
+			continue
+		}
+		c.Header(name, value)
+	}
+	c.Status(http.StatusOK)
+}
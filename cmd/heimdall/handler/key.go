@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"net/http"
+	"time"
+)
+
+type KeyHandler struct {
+	keySetProvider signature.KeySetProvider
+	cacheMaxAge    time.Duration
+}
+
+func NewKeyHandler(keySetProvider signature.KeySetProvider, cacheMaxAge time.Duration) *KeyHandler {
+	return &KeyHandler{
+		keySetProvider: keySetProvider,
+		cacheMaxAge:    cacheMaxAge,
+	}
+}
+
+// GetJWKS godoc
+// @Summary      Get the public keys for verifying tokens
+// @Description  JSON Web Key Set (RFC 7517) of the signing and retired keys. Shared HMAC secrets are never published.
+// @Tags         key
+// @Produce      json
+// @Success      200
+// @Router       /.well-known/jwks.json [GET]
+func (h KeyHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.cacheMaxAge.Seconds())))
+	c.JSON(http.StatusOK, h.keySetProvider.PublicKeySet())
+}
@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/gin-gonic/gin"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/cmd/heimdall/handler"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("KeyHandler", func() {
+	var (
+		c       *gin.Context
+		rec     *httptest.ResponseRecorder
+		h       *handler.KeyHandler
+		keyring *signature.Keyring
+	)
+
+	BeforeEach(func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+		jwkKey, err := jwk.FromRaw(privateKey)
+		Expect(err).To(BeNil())
+		keyring, err = signature.NewKeyring(signature.Key{ID: "ed-1", Algorithm: "EdDSA", Key: jwkKey})
+		Expect(err).To(BeNil())
+
+		h = handler.NewKeyHandler(keyring, 15*time.Minute)
+		rec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(rec)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	})
+
+	JustBeforeEach(func() {
+		h.GetJWKS(c)
+	})
+
+	It("should return the public key set", func() {
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		set, err := jwk.Parse(rec.Body.Bytes())
+		Expect(err).To(BeNil())
+		Expect(set.Len()).To(Equal(1))
+
+		key, _ := set.Key(0)
+		Expect(key.KeyID()).To(Equal("ed-1"))
+		Expect(key.Algorithm().String()).To(Equal("EdDSA"))
+		Expect(key.KeyUsage()).To(Equal("sig"))
+		Expect(rec.Body.String()).ToNot(ContainSubstring(`"d"`))
+	})
+
+	It("should set Cache-Control header", func() {
+		Expect(rec.Header().Get("Cache-Control")).To(Equal("public, max-age=900"))
+	})
+})
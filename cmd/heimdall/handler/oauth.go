@@ -0,0 +1,486 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	sentrygin "github.com/getsentry/sentry-go/gin"
+	"github.com/gin-gonic/gin"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The errors of the OAuth endpoints are the error codes of RFC 6749 and RFC 7009
+var (
+	InvalidRequestError         = errors.New("invalid_request")
+	InvalidClientError          = errors.New("invalid_client")
+	UnauthorizedClientError     = errors.New("unauthorized_client")
+	UnsupportedTokenTypeError   = errors.New("unsupported_token_type")
+	UnsupportedGrantTypeError   = errors.New("unsupported_grant_type")
+	InvalidScopeError           = errors.New("invalid_scope")
+	InvalidTargetError          = errors.New("invalid_target")
+	TemporarilyUnavailableError = errors.New("temporarily_unavailable")
+)
+
+var GetClientFromContextError = errors.New("failed get client from context")
+
+// TokenTypeBearer is the OAuth token type of every token.
+const TokenTypeBearer = "Bearer"
+
+// ClientIDClaim is the custom claim of the client that the token is issued to.
+const ClientIDClaim = "client_id"
+
+// The grant types of /oauth/token.
+const (
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// The token types of RFC 8693 token exchange. Every access token is a JWT, so both are accepted and issued alike.
+const (
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+type OAuthHandler struct {
+	logger          *zap.SugaredLogger
+	tokenManager    token.Manager
+	exchangeManager token.ExchangeManager
+	refreshManager  token.RefreshManager
+	revocationStore revocation.Store
+	clients         *client.Registry
+	leeway          time.Duration
+	validTime       time.Duration
+}
+
+// NewOAuthHandler returns the handler of the OAuth endpoints. The tokens of the clients are valid for validTime,
+// or forever when it is zero, unless the client has its own TTL. Refresh tokens are only revoked when refreshMng is not nil.
+func NewOAuthHandler(logger *zap.SugaredLogger, tokenMng token.Manager, exchangeMng token.ExchangeManager, refreshMng token.RefreshManager, store revocation.Store, clients *client.Registry, leeway, validTime time.Duration) *OAuthHandler {
+	return &OAuthHandler{
+		logger:          logger,
+		tokenManager:    tokenMng,
+		exchangeManager: exchangeMng,
+		refreshManager:  refreshMng,
+		revocationStore: store,
+		clients:         clients,
+		leeway:          leeway,
+		validTime:       validTime,
+	}
+}
+
+// AuthenticateClient authenticates the client with HTTP Basic authentication (client_secret_basic),
+// or with the client_id and client_secret form parameters (client_secret_post).
+func (h OAuthHandler) AuthenticateClient(c *gin.Context) {
+	clientID, clientSecret, basic := c.Request.BasicAuth()
+	if basic {
+		if _, ok := c.GetPostForm("client_id"); ok {
+			_ = c.AbortWithError(http.StatusBadRequest, InvalidRequestError)
+			return
+		}
+		var idErr, secretErr error
+		clientID, idErr = url.QueryUnescape(clientID)
+		clientSecret, secretErr = url.QueryUnescape(clientSecret)
+		if idErr != nil || secretErr != nil {
+			h.abortInvalidClient(c)
+			return
+		}
+	} else {
+		clientID = c.PostForm("client_id")
+		clientSecret = c.PostForm("client_secret")
+	}
+
+	authenticatedClient, err := h.clients.Authenticate(clientID, clientSecret)
+	if err != nil {
+		h.abortInvalidClient(c)
+		return
+	}
+	c.Set("client", authenticatedClient)
+	c.Next()
+}
+
+func (h OAuthHandler) abortInvalidClient(c *gin.Context) {
+	c.Header("WWW-Authenticate", `Basic realm="heimdall"`)
+	_ = c.AbortWithError(http.StatusUnauthorized, InvalidClientError)
+}
+
+// AccessTokenResponse is the RFC 6749 access token response, with the issued_token_type of RFC 8693 token exchange.
+type AccessTokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in,omitempty"`
+	Scope           string `json:"scope,omitempty"`
+}
+
+// IssueToken godoc
+// @Summary      Issue an access token to the client (RFC 6749, RFC 8693)
+// @Description  The client_credentials grant issues the token of the client, and the token exchange grant issues the token that the actor, or else the client, acts on behalf of the subject of the subject token. Neither is allowed for public clients. The client_credentials scopes and the audiences default to every one that the client may request. The exchanged scopes default to the scopes of the subject token and must be a subset of them, so the scope is rejected if the subject token has none.
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Security     ClientCredentials
+// @Param grant_type formData string true "Grant type" Enums(client_credentials, urn:ietf:params:oauth:grant-type:token-exchange)
+// @Param scope formData string false "Space separated scopes"
+// @Param audience formData []string false "Audiences" collectionFormat(multi)
+// @Param subject_token formData string false "Token of the subject, required by token exchange"
+// @Param subject_token_type formData string false "Type of the subject token, required by token exchange" Enums(urn:ietf:params:oauth:token-type:access_token, urn:ietf:params:oauth:token-type:jwt)
+// @Param actor_token formData string false "Token of the actor"
+// @Param actor_token_type formData string false "Type of the actor token, required with the actor token" Enums(urn:ietf:params:oauth:token-type:access_token, urn:ietf:params:oauth:token-type:jwt)
+// @Param requested_token_type formData string false "Type of the issued token" Enums(urn:ietf:params:oauth:token-type:access_token, urn:ietf:params:oauth:token-type:jwt)
+// @Success      200  {object}  AccessTokenResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /oauth/token [POST]
+func (h OAuthHandler) IssueToken(c *gin.Context) {
+	grantType := c.PostForm("grant_type")
+	if len(grantType) == 0 {
+		_ = c.AbortWithError(http.StatusBadRequest, InvalidRequestError)
+		return
+	}
+	if grantType != GrantTypeClientCredentials && grantType != GrantTypeTokenExchange {
+		_ = c.AbortWithError(http.StatusBadRequest, UnsupportedGrantTypeError)
+		return
+	}
+	authenticatedClient, ok := h.getClient(c)
+	if !ok {
+		return
+	}
+	if authenticatedClient.IsPublic() {
+		_ = c.AbortWithError(http.StatusBadRequest, UnauthorizedClientError)
+		return
+	}
+	audiences := authenticatedClient.Audiences
+	if requested, ok := c.GetPostFormArray("audience"); ok {
+		audiences = requested
+		for _, audience := range audiences {
+			if !authenticatedClient.AllowsAudience(audience) {
+				_ = c.AbortWithError(http.StatusBadRequest, InvalidTargetError)
+				return
+			}
+		}
+	}
+
+	now := time.Now()
+	payload := config.Payload{
+		MetadataPayload: config.MetadataPayload{
+			Audience: uniqueStrings(audiences),
+			IssuedAt: config.NewNumericDate(now),
+		},
+	}
+	validTime := h.validTime
+	if authenticatedClient.TokenTTL > 0 {
+		validTime = time.Duration(authenticatedClient.TokenTTL) * time.Second
+	}
+	if validTime > 0 {
+		payload.ExpiredAt = config.NewNumericDate(now.Add(validTime))
+	}
+
+	var accessToken, issuedTokenType string
+	if grantType == GrantTypeTokenExchange {
+		accessToken, ok = h.exchangeToken(c, authenticatedClient, &payload)
+		issuedTokenType = TokenTypeAccessToken
+	} else {
+		accessToken, ok = h.issueClientToken(c, authenticatedClient, &payload)
+	}
+	if !ok {
+		return
+	}
+
+	var expiresIn int64
+	if payload.ExpiredAt != nil {
+		expiresIn = payload.ExpiredAt.Unix() - now.Unix()
+	}
+	scope, _ := payload.CustomPayload[token.ScopeClaim].(string)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, AccessTokenResponse{
+		AccessToken:     accessToken,
+		IssuedTokenType: issuedTokenType,
+		TokenType:       TokenTypeBearer,
+		ExpiresIn:       expiresIn,
+		Scope:           scope,
+	})
+}
+
+// issueClientToken generates the client_credentials token of the client, or aborts and returns false.
+func (h OAuthHandler) issueClientToken(c *gin.Context, authenticatedClient *client.Client, payload *config.Payload) (string, bool) {
+	scopes := authenticatedClient.Scopes
+	if scope, ok := c.GetPostForm("scope"); ok {
+		scopes = strings.Fields(scope)
+		for _, s := range scopes {
+			if !authenticatedClient.AllowsScope(s) {
+				_ = c.AbortWithError(http.StatusBadRequest, InvalidScopeError)
+				return "", false
+			}
+		}
+	}
+
+	payload.CustomPayload = config.CustomPayload{ClientIDClaim: authenticatedClient.ID}
+	if scope := strings.Join(uniqueStrings(scopes), " "); len(scope) > 0 {
+		payload.CustomPayload[token.ScopeClaim] = scope
+	}
+	payload.Subject = authenticatedClient.ID
+	accessToken, err := h.tokenManager.Generate(*payload)
+	if err != nil {
+		h.abortGeneration(c, err, "h.tokenManager.Generate error", authenticatedClient)
+		return "", false
+	}
+	return accessToken, true
+}
+
+// exchangeToken generates the token that the actor of the actor token, or else the client, acts on behalf of the subject
+// of the subject token, or aborts and returns false.
+// The token keeps the custom claims of the subject token, except for its client and scopes.
+func (h OAuthHandler) exchangeToken(c *gin.Context, authenticatedClient *client.Client, payload *config.Payload) (string, bool) {
+	subjectToken := c.PostForm("subject_token")
+	actorToken := c.PostForm("actor_token")
+	requestedTokenType, requested := c.GetPostForm("requested_token_type")
+	if len(subjectToken) == 0 || !isTokenType(c.PostForm("subject_token_type")) ||
+		(len(actorToken) > 0) != isTokenType(c.PostForm("actor_token_type")) ||
+		(requested && !isTokenType(requestedTokenType)) {
+		_ = c.AbortWithError(http.StatusBadRequest, InvalidRequestError)
+		return "", false
+	}
+
+	subject, err := h.tokenManager.Parse(subjectToken)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, InvalidRequestError)
+		return "", false
+	}
+	actor := authenticatedClient.ID
+	if len(actorToken) > 0 {
+		actorPayload, err := h.tokenManager.Parse(actorToken)
+		if err != nil || len(token.Subject(actorPayload)) == 0 {
+			_ = c.AbortWithError(http.StatusBadRequest, InvalidRequestError)
+			return "", false
+		}
+		actor = token.Subject(actorPayload)
+	}
+
+	// The token cannot gain scopes, so an unscoped subject token cannot be exchanged with scopes
+	subjectScope, _ := subject.CustomPayload[token.ScopeClaim].(string)
+	subjectScopes := strings.Fields(subjectScope)
+	scopes := subjectScopes
+	if scope, ok := c.GetPostForm("scope"); ok {
+		scopes = strings.Fields(scope)
+		for _, s := range scopes {
+			if !containsString(subjectScopes, s) {
+				_ = c.AbortWithError(http.StatusBadRequest, InvalidScopeError)
+				return "", false
+			}
+		}
+	}
+
+	payload.CustomPayload = config.CustomPayload{}
+	for name, value := range subject.CustomPayload {
+		payload.CustomPayload[name] = value
+	}
+	for _, name := range []string{token.ActorClaim, token.FamilyClaim, token.TokenUseClaim, token.ScopeClaim} {
+		delete(payload.CustomPayload, name)
+	}
+	payload.CustomPayload[ClientIDClaim] = authenticatedClient.ID
+	if scope := strings.Join(uniqueStrings(scopes), " "); len(scope) > 0 {
+		payload.CustomPayload[token.ScopeClaim] = scope
+	}
+	accessToken, err := h.exchangeManager.Delegate(subject, actor, *payload)
+	if err != nil {
+		h.abortGeneration(c, err, "h.exchangeManager.Delegate error", authenticatedClient)
+		return "", false
+	}
+	// The token never outlives the subject token, which sets the expires_in of the response
+	if subject.ExpiredAt != nil && (payload.ExpiredAt == nil || payload.ExpiredAt.After(subject.ExpiredAt.Time)) {
+		payload.ExpiredAt = subject.ExpiredAt
+	}
+	return accessToken, true
+}
+
+func (h OAuthHandler) abortGeneration(c *gin.Context, err error, msg string, authenticatedClient *client.Client) {
+	h.logger.Errorw(msg, "error", err, "client_id", authenticatedClient.ID)
+	_ = c.AbortWithError(http.StatusInternalServerError, TokenGenerationError)
+	if hub := sentrygin.GetHubFromContext(c); hub != nil {
+		hub.CaptureException(err)
+	}
+}
+
+func isTokenType(tokenType string) bool {
+	return tokenType == TokenTypeAccessToken || tokenType == TokenTypeJWT
+}
+
+// RevokeToken godoc
+// @Summary      Revoke the token (RFC 7009)
+// @Description  Responds 200 even if the token is invalid, expired or already revoked. The client authenticates with HTTP Basic authentication or the client_id and client_secret parameters.
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Security     ClientCredentials
+// @Param token formData string true "Token"
+// @Param token_type_hint formData string false "Token type hint" Enums(access_token, refresh_token)
+// @Success      200
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      503  {object}  ErrorResponse
+// @Router       /revoke [POST]
+func (h OAuthHandler) RevokeToken(c *gin.Context) {
+	tokenString := c.PostForm("token")
+	if len(tokenString) == 0 {
+		_ = c.AbortWithError(http.StatusBadRequest, InvalidRequestError)
+		return
+	}
+
+	// token_type_hint is only an optimization for looking up the token, which every token type here does not need
+	payload, err := h.tokenManager.Parse(tokenString)
+	isRefreshToken := false
+	if errors.Is(err, token.TokenUseError) && h.refreshManager != nil {
+		payload, err = h.refreshManager.ParseRefreshToken(tokenString)
+		isRefreshToken = err == nil
+	}
+	if err != nil {
+		c.Status(http.StatusOK)
+		return
+	}
+	authenticatedClient, ok := h.getClient(c)
+	if !ok {
+		return
+	}
+	if !h.isIssuedTo(payload, authenticatedClient) {
+		_ = c.AbortWithError(http.StatusBadRequest, UnauthorizedClientError)
+		return
+	}
+	if len(payload.ID) == 0 {
+		_ = c.AbortWithError(http.StatusBadRequest, UnsupportedTokenTypeError)
+		return
+	}
+
+	if isRefreshToken {
+		// As RFC 7009 recommends, the access tokens of the same grant, which are its family, are revoked too
+		err = h.refreshManager.Revoke(c.Request.Context(), payload)
+	} else {
+		var expiredAt time.Time
+		if payload.ExpiredAt != nil {
+			expiredAt = payload.ExpiredAt.Add(h.leeway)
+		}
+		err = h.revocationStore.RevokeToken(c.Request.Context(), payload.ID, expiredAt)
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to revoke token", "error", err, "jti", payload.ID)
+		_ = c.AbortWithError(http.StatusServiceUnavailable, TemporarilyUnavailableError)
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(err)
+		}
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// IntrospectionResponse is the RFC 7662 introspection response, the claims of the active token along with active and token_type.
+type IntrospectionResponse struct {
+	Active    bool            `json:"active"`
+	TokenType string          `json:"token_type,omitempty"`
+	Payload   *config.Payload `json:"-"`
+}
+
+func (r IntrospectionResponse) MarshalJSON() ([]byte, error) {
+	claims := config.CustomPayload{}
+	if r.Payload != nil {
+		rawPayload, err := json.Marshal(r.Payload)
+		if err != nil {
+			return nil, err
+		}
+		claims, err = config.DecodeCustomPayload(bytes.NewReader(rawPayload))
+		if err != nil {
+			return nil, err
+		}
+	}
+	claims["active"] = r.Active
+	if len(r.TokenType) > 0 {
+		claims["token_type"] = r.TokenType
+	}
+	return json.Marshal(claims)
+}
+
+// IntrospectToken godoc
+// @Summary      Introspect the token (RFC 7662)
+// @Description  Responds the claims of the token along with "active": true, or only "active": false if the token is invalid, expired or revoked. Public clients are not allowed.
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Security     ClientCredentials
+// @Param token formData string true "Token"
+// @Param token_type_hint formData string false "Token type hint" Enums(access_token, refresh_token)
+// @Success      200  {object}  IntrospectionResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /introspect [POST]
+func (h OAuthHandler) IntrospectToken(c *gin.Context) {
+	authenticatedClient, ok := h.getClient(c)
+	if !ok {
+		return
+	}
+	if authenticatedClient.IsPublic() {
+		h.abortInvalidClient(c)
+		return
+	}
+	tokenString := c.PostForm("token")
+	if len(tokenString) == 0 {
+		_ = c.AbortWithError(http.StatusBadRequest, InvalidRequestError)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	payload, err := h.tokenManager.Parse(tokenString)
+	if err != nil {
+		c.JSON(http.StatusOK, IntrospectionResponse{Active: false})
+		return
+	}
+	c.JSON(http.StatusOK, IntrospectionResponse{Active: true, TokenType: TokenTypeBearer, Payload: payload})
+}
+
+func (h OAuthHandler) getClient(c *gin.Context) (*client.Client, bool) {
+	clientValue, ok := c.Get("client")
+	authenticatedClient, isClient := clientValue.(*client.Client)
+	if !ok || !isClient {
+		h.logger.Error("Failed get client from context")
+		_ = c.AbortWithError(http.StatusInternalServerError, GetClientFromContextError)
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(GetClientFromContextError)
+		}
+		return nil, false
+	}
+	return authenticatedClient, true
+}
+
+// uniqueStrings returns the values without duplicates, in the order of their first occurrences.
+func uniqueStrings(values []string) []string {
+	unique := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isIssuedTo reports whether the token is issued to the client. Tokens without client_id claim are issued to every client.
+func (h OAuthHandler) isIssuedTo(payload *config.Payload, authenticatedClient *client.Client) bool {
+	clientID, ok := payload.CustomPayload[ClientIDClaim]
+	return !ok || clientID == authenticatedClient.ID
+}
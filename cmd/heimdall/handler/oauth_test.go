@@ -0,0 +1,587 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/cmd/heimdall/handler"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"github.com/thetkpark/heimdall/test/mock_revocation"
+	"github.com/thetkpark/heimdall/test/mock_token"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var _ = Describe("OAuthHandler", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		router           *gin.Engine
+		rec              *httptest.ResponseRecorder
+		req              *http.Request
+		path             string
+		form             url.Values
+		mockTokenManager *mock_token.MockManager
+		mockExchanges    *mock_token.MockExchangeManager
+		mockRevocations  *mock_revocation.MockStore
+		payload          *config.Payload
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockTokenManager = mock_token.NewMockManager(mockCtrl)
+		mockExchanges = mock_token.NewMockExchangeManager(mockCtrl)
+		mockRevocations = mock_revocation.NewMockStore(mockCtrl)
+		secretHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+		Expect(err).To(BeNil())
+		clients, err := client.NewRegistry(
+			client.Client{ID: "frontend", SecretHash: string(secretHash), Scopes: []string{"read", "write"}, Audiences: []string{"api"}},
+			client.Client{ID: "mobile"},
+		)
+		Expect(err).To(BeNil())
+		h := handler.NewOAuthHandler(zap.NewNop().Sugar(), mockTokenManager, mockExchanges, nil, mockRevocations, clients, time.Minute, time.Hour)
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(handler.HTTPErrorHandler)
+		router.POST("/oauth/token", h.AuthenticateClient, h.IssueToken)
+		router.POST("/revoke", h.AuthenticateClient, h.RevokeToken)
+		router.POST("/introspect", h.AuthenticateClient, h.IntrospectToken)
+
+		rec = httptest.NewRecorder()
+		form = url.Values{"token": {"valid.token.string"}}
+		payload = &config.Payload{
+			CustomPayload: config.CustomPayload{"user_id": json.Number("99")},
+			MetadataPayload: config.MetadataPayload{
+				ID:        "token-id",
+				ExpiredAt: config.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		if req == nil {
+			req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetBasicAuth("frontend", "secret")
+		}
+		router.ServeHTTP(rec, req)
+	})
+
+	AfterEach(func() {
+		req = nil
+		mockCtrl.Finish()
+	})
+
+	Context("RevokeToken", func() {
+		BeforeEach(func() {
+			path = "/revoke"
+		})
+
+		When("Token is valid", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(payload, nil).Times(1)
+				mockRevocations.EXPECT().RevokeToken(gomock.Any(), "token-id", payload.ExpiredAt.Add(time.Minute)).Return(nil).Times(1)
+			})
+
+			It("should revoke the token", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		When("Client authenticates with form parameters", func() {
+			BeforeEach(func() {
+				form.Set("client_id", "frontend")
+				form.Set("client_secret", "secret")
+				req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(payload, nil).Times(1)
+				mockRevocations.EXPECT().RevokeToken(gomock.Any(), "token-id", gomock.Any()).Return(nil).Times(1)
+			})
+
+			It("should revoke the token", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		When("Public client authenticates with client_id", func() {
+			BeforeEach(func() {
+				form.Set("client_id", "mobile")
+				req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(payload, nil).Times(1)
+				mockRevocations.EXPECT().RevokeToken(gomock.Any(), "token-id", gomock.Any()).Return(nil).Times(1)
+			})
+
+			It("should revoke the token", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		When("Token is invalid", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(nil, errors.New("invalid token")).Times(1)
+			})
+
+			It("should return 200", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		When("Token is missing", func() {
+			BeforeEach(func() {
+				form.Del("token")
+			})
+
+			It("should return invalid_request", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_request"}`))
+			})
+		})
+
+		When("Client secret is wrong", func() {
+			BeforeEach(func() {
+				req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.SetBasicAuth("frontend", "wrong")
+			})
+
+			It("should return invalid_client", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+				Expect(rec.Header().Get("WWW-Authenticate")).To(Equal(`Basic realm="heimdall"`))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_client"}`))
+			})
+		})
+
+		When("Client uses more than one authentication method", func() {
+			BeforeEach(func() {
+				form.Set("client_id", "frontend")
+				req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.SetBasicAuth("frontend", "secret")
+			})
+
+			It("should return invalid_request", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_request"}`))
+			})
+		})
+
+		When("Token is issued to another client", func() {
+			BeforeEach(func() {
+				payload.CustomPayload[handler.ClientIDClaim] = "mobile"
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(payload, nil).Times(1)
+			})
+
+			It("should return unauthorized_client", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "unauthorized_client"}`))
+			})
+		})
+
+		When("Token has no jti", func() {
+			BeforeEach(func() {
+				payload.ID = ""
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(payload, nil).Times(1)
+			})
+
+			It("should return unsupported_token_type", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "unsupported_token_type"}`))
+			})
+		})
+
+		When("Revocation store is unavailable", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(payload, nil).Times(1)
+				mockRevocations.EXPECT().RevokeToken(gomock.Any(), "token-id", gomock.Any()).Return(errors.New("some error")).Times(1)
+			})
+
+			It("should return 503", func() {
+				Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+			})
+		})
+	})
+
+	Context("IssueToken", func() {
+		BeforeEach(func() {
+			path = "/oauth/token"
+			form = url.Values{"grant_type": {"client_credentials"}}
+		})
+
+		When("Client requests the default scopes", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Generate(gomock.Any()).DoAndReturn(func(payload config.Payload) (string, error) {
+					Expect(payload.CustomPayload).To(Equal(config.CustomPayload{"client_id": "frontend", "scope": "read write"}))
+					Expect(payload.Subject).To(Equal("frontend"))
+					Expect(payload.Audience).To(Equal(config.Audience{"api"}))
+					Expect(payload.ExpiredAt.Time).To(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+					return "token", nil
+				}).Times(1)
+			})
+
+			It("should return the access token", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("Cache-Control")).To(Equal("no-store"))
+				Expect(rec.Body.String()).To(MatchJSON(`{"access_token": "token", "token_type": "Bearer", "expires_in": 3600, "scope": "read write"}`))
+			})
+		})
+
+		When("Client requests allowed scopes", func() {
+			BeforeEach(func() {
+				form.Set("scope", "read read")
+				mockTokenManager.EXPECT().Generate(gomock.Any()).DoAndReturn(func(payload config.Payload) (string, error) {
+					Expect(payload.CustomPayload).To(HaveKeyWithValue("scope", "read"))
+					return "token", nil
+				}).Times(1)
+			})
+
+			It("should return the access token of the scopes", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(ContainSubstring(`"scope":"read"`))
+			})
+		})
+
+		When("Client requests other scopes", func() {
+			BeforeEach(func() {
+				form.Set("scope", "read admin")
+			})
+
+			It("should return 400 invalid_scope", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_scope"}`))
+			})
+		})
+
+		When("Client requests other audiences", func() {
+			BeforeEach(func() {
+				form.Add("audience", "api")
+				form.Add("audience", "billing")
+			})
+
+			It("should return 400 invalid_target", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_target"}`))
+			})
+		})
+
+		When("Grant type is not supported", func() {
+			BeforeEach(func() {
+				form.Set("grant_type", "password")
+			})
+
+			It("should return 400 unsupported_grant_type", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "unsupported_grant_type"}`))
+			})
+		})
+
+		When("Grant type is missing", func() {
+			BeforeEach(func() {
+				form = url.Values{}
+			})
+
+			It("should return 400 invalid_request", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_request"}`))
+			})
+		})
+
+		When("Client is public", func() {
+			BeforeEach(func() {
+				form.Set("client_id", "mobile")
+				req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			})
+
+			It("should return 400 unauthorized_client", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "unauthorized_client"}`))
+			})
+		})
+
+		When("Client credentials are invalid", func() {
+			BeforeEach(func() {
+				req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.SetBasicAuth("frontend", "wrong")
+			})
+
+			It("should return 401 invalid_client", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		When("Token generation error", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Generate(gomock.Any()).Return("", errors.New("some error")).Times(1)
+			})
+
+			It("should return 500", func() {
+				Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Context("IssueToken with token exchange", func() {
+		BeforeEach(func() {
+			path = "/oauth/token"
+			form = url.Values{
+				"grant_type":         {handler.GrantTypeTokenExchange},
+				"subject_token":      {"subject.token.string"},
+				"subject_token_type": {handler.TokenTypeAccessToken},
+			}
+			payload.CustomPayload["scope"] = "read write"
+			payload.CustomPayload["fam"] = "family"
+			payload.CustomPayload["act"] = map[string]interface{}{"sub": "gateway"}
+		})
+
+		When("Subject token is valid", func() {
+			BeforeEach(func() {
+				form.Set("scope", "read")
+				mockTokenManager.EXPECT().Parse("subject.token.string").Return(payload, nil).Times(1)
+				mockExchanges.EXPECT().Delegate(payload, "frontend", gomock.Any()).DoAndReturn(func(subject *config.Payload, actor string, p config.Payload) (string, error) {
+					Expect(p.CustomPayload).To(Equal(config.CustomPayload{"user_id": json.Number("99"), "client_id": "frontend", "scope": "read"}))
+					Expect(p.Audience).To(Equal(config.Audience{"api"}))
+					return "token", nil
+				}).Times(1)
+			})
+
+			It("should return the down-scoped token acted by the client", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(MatchJSON(`{"access_token": "token", "issued_token_type": "urn:ietf:params:oauth:token-type:access_token", "token_type": "Bearer", "expires_in": 3600, "scope": "read"}`))
+			})
+		})
+
+		When("Actor token is valid", func() {
+			BeforeEach(func() {
+				form.Set("actor_token", "actor.token.string")
+				form.Set("actor_token_type", handler.TokenTypeJWT)
+				mockTokenManager.EXPECT().Parse("subject.token.string").Return(payload, nil).Times(1)
+				mockTokenManager.EXPECT().Parse("actor.token.string").Return(&config.Payload{MetadataPayload: config.MetadataPayload{Subject: "billing"}}, nil).Times(1)
+				mockExchanges.EXPECT().Delegate(payload, "billing", gomock.Any()).Return("token", nil).Times(1)
+			})
+
+			It("should return the token acted by the actor with the scopes of the subject token", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(ContainSubstring(`"scope":"read write"`))
+			})
+		})
+
+		When("Subject token expires before the token", func() {
+			BeforeEach(func() {
+				payload.ExpiredAt = config.NewNumericDate(time.Now().Add(time.Minute))
+				mockTokenManager.EXPECT().Parse("subject.token.string").Return(payload, nil).Times(1)
+				mockExchanges.EXPECT().Delegate(payload, "frontend", gomock.Any()).Return("token", nil).Times(1)
+			})
+
+			It("should return the expiry of the subject token", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(ContainSubstring(`"expires_in":60`))
+			})
+		})
+
+		When("Client requests scopes that the subject token does not have", func() {
+			BeforeEach(func() {
+				form.Set("scope", "read admin")
+				mockTokenManager.EXPECT().Parse("subject.token.string").Return(payload, nil).Times(1)
+			})
+
+			It("should return 400 invalid_scope", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_scope"}`))
+			})
+		})
+
+		When("Subject token has no scopes and client requests scopes of another client", func() {
+			BeforeEach(func() {
+				delete(payload.CustomPayload, "scope")
+				form.Set("scope", "admin")
+				mockTokenManager.EXPECT().Parse("subject.token.string").Return(payload, nil).Times(1)
+			})
+
+			It("should return 400 invalid_scope", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_scope"}`))
+			})
+		})
+
+		When("Subject token has no scopes and client requests its own scopes", func() {
+			BeforeEach(func() {
+				delete(payload.CustomPayload, "scope")
+				form.Set("scope", "read")
+				mockTokenManager.EXPECT().Parse("subject.token.string").Return(payload, nil).Times(1)
+			})
+
+			It("should return 400 invalid_scope", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_scope"}`))
+			})
+		})
+
+		When("Subject token is invalid", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("subject.token.string").Return(nil, errors.New("invalid")).Times(1)
+			})
+
+			It("should return 400 invalid_request", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_request"}`))
+			})
+		})
+
+		When("Actor token type is missing", func() {
+			BeforeEach(func() {
+				form.Set("actor_token", "actor.token.string")
+			})
+
+			It("should return 400 invalid_request", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_request"}`))
+			})
+		})
+
+		When("Subject token type is not supported", func() {
+			BeforeEach(func() {
+				form.Set("subject_token_type", "urn:ietf:params:oauth:token-type:saml2")
+			})
+
+			It("should return 400 invalid_request", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_request"}`))
+			})
+		})
+	})
+
+	Context("IntrospectToken", func() {
+		BeforeEach(func() {
+			path = "/introspect"
+		})
+
+		When("Token is active", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(payload, nil).Times(1)
+			})
+
+			It("should return the claims", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("Cache-Control")).To(Equal("no-store"))
+				var res map[string]interface{}
+				Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(Succeed())
+				Expect(res).To(HaveKeyWithValue("active", true))
+				Expect(res).To(HaveKeyWithValue("token_type", "Bearer"))
+				Expect(res).To(HaveKeyWithValue("user_id", float64(99)))
+				Expect(res).To(HaveKeyWithValue("jti", "token-id"))
+				Expect(res).To(HaveKeyWithValue("exp", float64(payload.ExpiredAt.Unix())))
+			})
+		})
+
+		When("Token is inactive", func() {
+			BeforeEach(func() {
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(nil, errors.New("token is expired")).Times(1)
+			})
+
+			It("should return only active false", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(MatchJSON(`{"active": false}`))
+			})
+		})
+
+		When("Token is missing", func() {
+			BeforeEach(func() {
+				form.Del("token")
+			})
+
+			It("should return invalid_request", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_request"}`))
+			})
+		})
+
+		When("Client is public", func() {
+			BeforeEach(func() {
+				form.Set("client_id", "mobile")
+				req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			})
+
+			It("should return invalid_client", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error": "invalid_client"}`))
+			})
+		})
+
+		When("Client is not authenticated", func() {
+			BeforeEach(func() {
+				req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			})
+
+			It("should return invalid_client", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
+
+var _ = Describe("OAuthHandler with refresh tokens", func() {
+	var (
+		ctx            = context.Background()
+		router         *gin.Engine
+		rec            *httptest.ResponseRecorder
+		tokenManager   token.Manager
+		refreshManager token.RefreshManager
+		pair           *token.TokenPair
+	)
+
+	BeforeEach(func() {
+		store := revocation.NewMemoryStore()
+		mng := token.NewTokenManager(signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"), nil, token.WithRevocationStore(store))
+		tokenManager = mng
+		var err error
+		refreshManager, err = token.NewRefreshManager(mng, time.Minute, time.Hour)
+		Expect(err).To(BeNil())
+		pair, err = refreshManager.Generate(config.Payload{CustomPayload: config.CustomPayload{"user_id": json.Number("99")}})
+		Expect(err).To(BeNil())
+
+		secretHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+		Expect(err).To(BeNil())
+		clients, err := client.NewRegistry(client.Client{ID: "frontend", SecretHash: string(secretHash)})
+		Expect(err).To(BeNil())
+		h := handler.NewOAuthHandler(zap.NewNop().Sugar(), mng, token.NewExchangeManager(mng), refreshManager, store, clients, time.Minute, time.Hour)
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(handler.HTTPErrorHandler)
+		router.POST("/revoke", h.AuthenticateClient, h.RevokeToken)
+		rec = httptest.NewRecorder()
+	})
+
+	It("should revoke the refresh token and its family", func() {
+		req, _ := http.NewRequest(http.MethodPost, "/revoke", strings.NewReader(url.Values{
+			"token":           {pair.RefreshToken},
+			"token_type_hint": {"refresh_token"},
+		}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("frontend", "secret")
+		router.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		_, err := refreshManager.Refresh(ctx, pair.RefreshToken)
+		Expect(err).To(MatchError(token.TokenRevokedError))
+		_, err = tokenManager.Parse(pair.AccessToken)
+		Expect(err).To(MatchError(token.TokenRevokedError))
+	})
+})
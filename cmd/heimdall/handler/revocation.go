@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	sentrygin "github.com/getsentry/sentry-go/gin"
+	"github.com/gin-gonic/gin"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+var (
+	TokenRevocationError = errors.New("failed to revoke token")
+)
+
+type RevocationHandler struct {
+	logger          *zap.SugaredLogger
+	revocationStore revocation.Store
+	retention       time.Duration
+}
+
+type RevokeTokenRequest struct {
+	ID string `json:"jti" binding:"required"`
+}
+
+type RevokeUserTokensRequest struct {
+	IssuedBefore *config.NumericDate `json:"issued_before" swaggertype:"integer"`
+}
+
+// NewRevocationHandler returns the handler that revokes tokens in the store.
+// Revoked jti are kept for the retention, which must cover the remaining lifetime of the tokens, or forever when it is zero.
+func NewRevocationHandler(logger *zap.SugaredLogger, store revocation.Store, retention time.Duration) *RevocationHandler {
+	return &RevocationHandler{
+		logger:          logger,
+		revocationStore: store,
+		retention:       retention,
+	}
+}
+
+// RevokeToken godoc
+// @Summary      Revoke the token of the jti
+// @Tags         revocation
+// @Accept       json
+// @Security     CallerToken
+// @Param payload body RevokeTokenRequest true "Token ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /revocations/tokens [POST]
+func (h RevocationHandler) RevokeToken(c *gin.Context) {
+	var req RevokeTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, BadRequestBodyError)
+		return
+	}
+
+	var expiredAt time.Time
+	if h.retention > 0 {
+		expiredAt = time.Now().Add(h.retention)
+	}
+	if err := h.revocationStore.RevokeToken(c.Request.Context(), req.ID, expiredAt); err != nil {
+		h.logger.Errorw("h.revocationStore.RevokeToken error", "error", err, "jti", req.ID)
+		_ = c.AbortWithError(http.StatusInternalServerError, TokenRevocationError)
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(err)
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeUserTokens godoc
+// @Summary      Revoke every token of the user issued before the time
+// @Description  The user is the "sub" claim of the token, or the user_id claim of legacy tokens. issued_before defaults to now.
+// @Tags         revocation
+// @Accept       json
+// @Security     CallerToken
+// @Param user_id path string true "User ID"
+// @Param payload body RevokeUserTokensRequest false "Issued before"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /revocations/users/{user_id} [POST]
+func (h RevocationHandler) RevokeUserTokens(c *gin.Context) {
+	var req RevokeUserTokensRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, BadRequestBodyError)
+			return
+		}
+	}
+
+	userID := c.Param("user_id")
+	issuedBefore := time.Now()
+	if req.IssuedBefore != nil {
+		issuedBefore = req.IssuedBefore.Time
+	}
+	if err := h.revocationStore.RevokeSubject(c.Request.Context(), userID, issuedBefore); err != nil {
+		h.logger.Errorw("h.revocationStore.RevokeSubject error", "error", err, "user_id", userID)
+		_ = c.AbortWithError(http.StatusInternalServerError, TokenRevocationError)
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(err)
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
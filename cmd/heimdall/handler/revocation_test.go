@@ -0,0 +1,144 @@
+package handler_test
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/cmd/heimdall/handler"
+	"github.com/thetkpark/heimdall/test/mock_revocation"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+var _ = Describe("RevocationHandler", func() {
+	var (
+		mockCtrl        *gomock.Controller
+		c               *gin.Context
+		rec             *httptest.ResponseRecorder
+		h               *handler.RevocationHandler
+		handlerFunc     gin.HandlerFunc
+		mockRevocations *mock_revocation.MockStore
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockRevocations = mock_revocation.NewMockStore(mockCtrl)
+		h = handler.NewRevocationHandler(zap.NewNop().Sugar(), mockRevocations, time.Hour)
+		rec = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(rec)
+	})
+
+	JustBeforeEach(func() {
+		handlerFunc(c)
+		c.Writer.WriteHeaderNow()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("RevokeToken", func() {
+		BeforeEach(func() {
+			handlerFunc = h.RevokeToken
+		})
+
+		When("Correct request body", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jti": "token-id"}`))
+				mockRevocations.EXPECT().RevokeToken(gomock.Any(), "token-id", gomock.Any()).DoAndReturn(
+					func(_ interface{}, _ string, expiredAt time.Time) error {
+						Expect(expiredAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+						return nil
+					}).Times(1)
+			})
+
+			It("should return 204", func() {
+				Expect(rec.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		When("Incorrect request body", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id": "token-id"}`))
+			})
+
+			It("should return 400", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Err).To(Equal(handler.BadRequestBodyError))
+			})
+		})
+
+		When("Store error", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jti": "token-id"}`))
+				mockRevocations.EXPECT().RevokeToken(gomock.Any(), "token-id", gomock.Any()).Return(errors.New("some error")).Times(1)
+			})
+
+			It("should return 500", func() {
+				Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Err).To(Equal(handler.TokenRevocationError))
+			})
+		})
+	})
+
+	Context("RevokeUserTokens", func() {
+		BeforeEach(func() {
+			handlerFunc = h.RevokeUserTokens
+			c.Params = gin.Params{{Key: "user_id", Value: "99"}}
+		})
+
+		When("Request has no body", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+				mockRevocations.EXPECT().RevokeSubject(gomock.Any(), "99", gomock.Any()).DoAndReturn(
+					func(_ interface{}, _ string, issuedBefore time.Time) error {
+						Expect(issuedBefore).To(BeTemporally("~", time.Now(), time.Minute))
+						return nil
+					}).Times(1)
+			})
+
+			It("should revoke the tokens issued before now", func() {
+				Expect(rec.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		When("Request has issued_before", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"issued_before": 1660000000}`))
+				mockRevocations.EXPECT().RevokeSubject(gomock.Any(), "99", time.Unix(1660000000, 0).UTC()).Return(nil).Times(1)
+			})
+
+			It("should revoke the tokens issued before the time", func() {
+				Expect(rec.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		When("Incorrect request body", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"issued_before": "yesterday"}`))
+			})
+
+			It("should return 400", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Err).To(Equal(handler.BadRequestBodyError))
+			})
+		})
+
+		When("Store error", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+				mockRevocations.EXPECT().RevokeSubject(gomock.Any(), "99", gomock.Any()).Return(errors.New("some error")).Times(1)
+			})
+
+			It("should return 500", func() {
+				Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Err).To(Equal(handler.TokenRevocationError))
+			})
+		})
+	})
+})
@@ -1,16 +1,19 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
-	"fmt"
 	sentrygin "github.com/getsentry/sentry-go/gin"
 	"github.com/gin-gonic/gin"
+	"github.com/thetkpark/heimdall/pkg/client"
 	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/header"
 	"github.com/thetkpark/heimdall/pkg/token"
 	"go.uber.org/zap"
 	"net/http"
-	"reflect"
-	"regexp"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -19,61 +22,125 @@ var (
 	TokenGenerationError       = errors.New("failed to generate token string")
 	GetPayloadFromContextError = errors.New("failed get payload from context")
 	PayloadTypeCastingError    = errors.New("failed to cast payload type")
-	TokenRegexCreationError    = errors.New("failed to create token regex")
-	TokenFormatError           = errors.New("token format is invalid")
+	TokenFormatError           = token.TokenFormatError
 	TokenParsingError          = errors.New("failed to parse token")
-	TokenExpiredError          = errors.New("token is expired")
+	TokenExpiredError          = token.TokenExpiredError
+	RefreshTokenDisabledError  = errors.New("refresh tokens are disabled")
+	ClaimHeaderError           = errors.New("failed to format claim headers")
+	BatchSizeError             = errors.New("batch size exceeds the limit")
+	CallerAuthenticationError  = errors.New("invalid caller credentials")
+	GetCallerFromContextError  = errors.New("failed get caller from context")
 )
 
 type TokenHandler struct {
-	logger       *zap.SugaredLogger
-	tokenManager token.Manager
-	validTime    time.Duration
+	logger           *zap.SugaredLogger
+	tokenManager     token.Manager
+	refreshManager   token.RefreshManager
+	issuers          *client.IssuerAuthenticator
+	validTime        time.Duration
+	maxBatchSize     int
+	batchWorkers     int
+	headerMapper     *header.Mapper
+	extractor        *token.Extractor
+	forwardedHeaders ForwardedHeaders
 }
 
 type TokenResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// BatchTokenResult is either the tokens or the error of an item of the batch.
+type BatchTokenResult struct {
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+type BatchTokenResponse struct {
+	Results []BatchTokenResult `json:"results"`
+}
+
+type VerifyTokenBatchRequest struct {
+	Tokens []string `json:"tokens" binding:"required,min=1"`
+}
+
+// VerifyTokenResult is the payload of the valid token, or the reason that the token is rejected.
+type VerifyTokenResult struct {
+	Valid   bool            `json:"valid"`
+	Payload *config.Payload `json:"payload,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+type VerifyTokenBatchResponse struct {
+	Results []VerifyTokenResult `json:"results"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
-func NewTokenHandler(logger *zap.SugaredLogger, tokenMng token.Manager, validTime time.Duration) *TokenHandler {
+// NewTokenHandler returns the handler of the tokens. Refresh tokens are disabled when refreshMng is nil.
+// The callers of the token generation are authenticated by issuers.
+// Batches are limited to maxBatchSize items, and their tokens are verified by batchWorkers goroutines.
+// The claims are set to the headers of /auth/header by headerMapper, and the tokens of /auth are read by extractor,
+// from the query of the URI of forwardedHeaders for the query sources.
+func NewTokenHandler(logger *zap.SugaredLogger, tokenMng token.Manager, refreshMng token.RefreshManager, issuers *client.IssuerAuthenticator, validTime time.Duration, maxBatchSize, batchWorkers int, headerMapper *header.Mapper, extractor *token.Extractor, forwardedHeaders ForwardedHeaders) *TokenHandler {
 	return &TokenHandler{
-		logger:       logger,
-		tokenManager: tokenMng,
-		validTime:    validTime,
+		logger:           logger,
+		tokenManager:     tokenMng,
+		refreshManager:   refreshMng,
+		issuers:          issuers,
+		validTime:        validTime,
+		maxBatchSize:     maxBatchSize,
+		batchWorkers:     batchWorkers,
+		headerMapper:     headerMapper,
+		extractor:        extractor,
+		forwardedHeaders: forwardedHeaders,
 	}
 }
 
 // GenerateToken godoc
 // @Summary      Generate token with the payload
+// @Description  The refresh token is only returned when refresh tokens are enabled
 // @Tags         token
 // @Accept       json
 // @Produce      json
+// @Security     CallerToken
 // @Param payload body config.CustomPayload true "Payload"
 // @Success      201  {object}  TokenResponse
 // @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /generate [POST]
 func (h TokenHandler) GenerateToken(c *gin.Context) {
-	var customPayload config.CustomPayload
-	err := c.ShouldBindJSON(&customPayload)
+	caller, ok := h.getCaller(c)
+	if !ok {
+		return
+	}
+	customPayload, err := config.DecodeCustomPayload(c.Request.Body)
 	if err != nil {
 		_ = c.AbortWithError(http.StatusBadRequest, BadRequestBodyError)
 		return
 	}
-
-	payload := config.Payload{
-		CustomPayload: customPayload,
-		MetadataPayload: config.MetadataPayload{
-			IssuedAt:  time.Now().UTC(),
-			ExpiredAt: time.Now().Add(h.validTime).UTC(),
-		},
+	payload := h.newPayload(customPayload, time.Now())
+	if err := caller.Authorize(payload); err != nil {
+		_ = c.AbortWithError(http.StatusForbidden, err)
+		return
 	}
-	tokenString, err := h.tokenManager.Generate(payload)
+
+	res, err := h.generate(payload, caller.AllowsRefresh())
 	if err != nil {
+		var claimsErr *token.ClaimsError
+		if errors.As(err, &claimsErr) {
+			_ = c.AbortWithError(http.StatusBadRequest, claimsErr)
+			return
+		}
 		h.logger.Errorw("h.tokenManager.Generate error", "error", err, "payload", payload)
 		_ = c.AbortWithError(http.StatusInternalServerError, TokenGenerationError)
 		if hub := sentrygin.GetHubFromContext(c); hub != nil {
@@ -81,7 +148,185 @@ func (h TokenHandler) GenerateToken(c *gin.Context) {
 		}
 		return
 	}
-	c.JSON(http.StatusCreated, TokenResponse{Token: tokenString})
+	c.JSON(http.StatusCreated, res)
+}
+
+// GenerateTokenBatch godoc
+// @Summary      Generate the token of each payload
+// @Description  Every token of the batch is signed with the same key. The results are in the order of the payloads, with the error of each payload that fails.
+// @Tags         token
+// @Accept       json
+// @Produce      json
+// @Security     CallerToken
+// @Param payloads body []config.CustomPayload true "Payloads"
+// @Success      200  {object}  BatchTokenResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /generate/batch [POST]
+func (h TokenHandler) GenerateTokenBatch(c *gin.Context) {
+	caller, ok := h.getCaller(c)
+	if !ok {
+		return
+	}
+	var rawPayloads []json.RawMessage
+	if err := json.NewDecoder(c.Request.Body).Decode(&rawPayloads); err != nil || len(rawPayloads) == 0 {
+		_ = c.AbortWithError(http.StatusBadRequest, BadRequestBodyError)
+		return
+	}
+	if len(rawPayloads) > h.maxBatchSize {
+		_ = c.AbortWithError(http.StatusBadRequest, BatchSizeError)
+		return
+	}
+
+	now := time.Now()
+	results := make([]BatchTokenResult, len(rawPayloads))
+	payloads := make([]config.Payload, 0, len(rawPayloads))
+	indexes := make([]int, 0, len(rawPayloads))
+	for i, rawPayload := range rawPayloads {
+		customPayload, err := config.DecodeCustomPayload(bytes.NewReader(rawPayload))
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		payload := h.newPayload(customPayload, now)
+		if err := caller.Authorize(payload); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		payloads = append(payloads, payload)
+		indexes = append(indexes, i)
+	}
+
+	responses, errs := h.generateBatch(payloads, caller.AllowsRefresh())
+	for j, i := range indexes {
+		if errs[j] == nil {
+			results[i] = BatchTokenResult{Token: responses[j].Token, RefreshToken: responses[j].RefreshToken}
+			continue
+		}
+		var claimsErr *token.ClaimsError
+		if errors.As(errs[j], &claimsErr) {
+			results[i].Error = claimsErr.Error()
+			continue
+		}
+		h.logger.Errorw("h.tokenManager.GenerateBatch error", "error", errs[j], "payload", payloads[j])
+		results[i].Error = TokenGenerationError.Error()
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(errs[j])
+		}
+	}
+	c.JSON(http.StatusOK, BatchTokenResponse{Results: results})
+}
+
+// AuthenticateCaller authenticates the caller of the token generation with the admin token or the API key
+// in the Authorization header, or else with the verified TLS client certificate.
+func (h TokenHandler) AuthenticateCaller(c *gin.Context) {
+	var caller *client.Caller
+	err := CallerAuthenticationError
+	if authorization := c.GetHeader("Authorization"); len(authorization) > 0 {
+		if apiKey := strings.TrimPrefix(authorization, "Bearer "); apiKey != authorization {
+			caller, err = h.issuers.AuthenticateToken(apiKey)
+		}
+	} else if c.Request.TLS != nil && len(c.Request.TLS.VerifiedChains) > 0 {
+		caller, err = h.issuers.AuthenticateCertificate(c.Request.TLS.VerifiedChains[0][0])
+	}
+	if err != nil {
+		c.Header("WWW-Authenticate", "Bearer")
+		_ = c.AbortWithError(http.StatusUnauthorized, CallerAuthenticationError)
+		return
+	}
+	c.Set("caller", caller)
+	c.Next()
+}
+
+func (h TokenHandler) getCaller(c *gin.Context) (*client.Caller, bool) {
+	callerValue, ok := c.Get("caller")
+	caller, isCaller := callerValue.(*client.Caller)
+	if !ok || !isCaller {
+		h.logger.Error("Failed get caller from context")
+		_ = c.AbortWithError(http.StatusInternalServerError, GetCallerFromContextError)
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.CaptureException(GetCallerFromContextError)
+		}
+		return nil, false
+	}
+	return caller, true
+}
+
+func (h TokenHandler) newPayload(customPayload config.CustomPayload, now time.Time) config.Payload {
+	payload := config.Payload{
+		CustomPayload:   customPayload,
+		MetadataPayload: config.MetadataPayload{IssuedAt: config.NewNumericDate(now)},
+	}
+	if h.validTime > 0 {
+		payload.ExpiredAt = config.NewNumericDate(now.Add(h.validTime))
+	}
+	return payload
+}
+
+func (h TokenHandler) generateBatch(payloads []config.Payload, refresh bool) ([]TokenResponse, []error) {
+	responses := make([]TokenResponse, len(payloads))
+	if h.refreshManager == nil || !refresh {
+		tokens, errs := h.tokenManager.GenerateBatch(payloads)
+		for i, tokenString := range tokens {
+			responses[i].Token = tokenString
+		}
+		return responses, errs
+	}
+	pairs, errs := h.refreshManager.GenerateBatch(payloads)
+	for i, pair := range pairs {
+		if pair != nil {
+			responses[i] = TokenResponse{Token: pair.AccessToken, RefreshToken: pair.RefreshToken}
+		}
+	}
+	return responses, errs
+}
+
+func (h TokenHandler) generate(payload config.Payload, refresh bool) (TokenResponse, error) {
+	if h.refreshManager == nil || !refresh {
+		tokenString, err := h.tokenManager.Generate(payload)
+		return TokenResponse{Token: tokenString}, err
+	}
+	pair, err := h.refreshManager.Generate(payload)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	return TokenResponse{Token: pair.AccessToken, RefreshToken: pair.RefreshToken}, nil
+}
+
+// RefreshToken godoc
+// @Summary      Exchange the refresh token for a new access token and refresh token
+// @Description  Each refresh token can only be used once. Using it again revokes every token issued by refreshing it.
+// @Tags         token
+// @Accept       json
+// @Produce      json
+// @Param payload body RefreshTokenRequest true "Refresh token"
+// @Success      200  {object}  TokenResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /refresh [POST]
+func (h TokenHandler) RefreshToken(c *gin.Context) {
+	if h.refreshManager == nil {
+		_ = c.AbortWithError(http.StatusNotFound, RefreshTokenDisabledError)
+		return
+	}
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, BadRequestBodyError)
+		return
+	}
+
+	pair, err := h.refreshManager.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		var validationErr *token.ValidationError
+		if errors.As(err, &validationErr) {
+			_ = c.AbortWithError(http.StatusUnauthorized, validationErr.Err)
+			return
+		}
+		_ = c.AbortWithError(http.StatusUnauthorized, TokenParsingError)
+		return
+	}
+	c.JSON(http.StatusOK, TokenResponse{Token: pair.AccessToken, RefreshToken: pair.RefreshToken})
 }
 
 // ParsePayload godoc
@@ -90,7 +335,10 @@ func (h TokenHandler) GenerateToken(c *gin.Context) {
 // @Security	 JWSToken
 // @Produce      json
 // @Success      200  {object}  config.Payload
+// @Param X-Forwarded-Method header string false "Method of the original request, or X-Original-Method"
+// @Param X-Forwarded-Uri header string false "URI of the original request, or X-Original-URI"
 // @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /auth/body [GET]
 func (h TokenHandler) ParsePayload(c *gin.Context) {
@@ -117,10 +365,14 @@ func (h TokenHandler) ParsePayload(c *gin.Context) {
 
 // ParsePayloadAndSetHeader godoc
 // @Summary      Verify token and set custom payload to header
+// @Description  Every custom claim is set to the X-<CLAIM-NAME> header, e.g. user_id to X-USER-ID, unless CLAIM_HEADERS_FILE maps the claims
 // @Tags         token
 // @Security	 JWSToken
 // @Success      200
+// @Param X-Forwarded-Method header string false "Method of the original request, or X-Original-Method"
+// @Param X-Forwarded-Uri header string false "URI of the original request, or X-Original-URI"
 // @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /auth/header [GET]
 func (h TokenHandler) ParsePayloadAndSetHeader(c *gin.Context) {
@@ -143,40 +395,64 @@ func (h TokenHandler) ParsePayloadAndSetHeader(c *gin.Context) {
 		return
 	}
 
-	for i := 0; i < reflect.TypeOf(payload.CustomPayload).NumField(); i++ {
-		field := reflect.TypeOf(payload.CustomPayload).Field(i)
-		headerName := field.Tag.Get("header")
-		if len(headerName) > 0 {
-			val := fmt.Sprintf("%v", reflect.ValueOf(payload.CustomPayload).Field(i))
-			c.Header(headerName, val)
-		}
-	}
-	c.Status(http.StatusOK)
-}
-
-func (h TokenHandler) AuthenticateToken(c *gin.Context) {
-	bearerToken := c.GetHeader("Authorization")
-	reg, err := regexp.Compile(`Bearer (.+\..+\..+)`)
+	headers, err := h.headerMapper.Headers(payload)
 	if err != nil {
+		h.logger.Errorw("Failed to format claim headers", "error", err)
+		_ = c.AbortWithError(http.StatusInternalServerError, ClaimHeaderError)
 		if hub := sentrygin.GetHubFromContext(c); hub != nil {
 			hub.CaptureException(err)
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, TokenRegexCreationError)
 		return
 	}
-	if !reg.MatchString(bearerToken) {
-		_ = c.AbortWithError(http.StatusUnauthorized, TokenFormatError)
+	for name, value := range headers {
+		c.Header(name, value)
+	}
+	c.Status(http.StatusOK)
+}
+
+// VerifyTokenBatch godoc
+// @Summary      Verify tokens and parse their payloads
+// @Description  The results are in the order of the tokens, with the payload of each valid token or the reason that it is rejected.
+// @Tags         token
+// @Accept       json
+// @Produce      json
+// @Param request body VerifyTokenBatchRequest true "Tokens"
+// @Success      200  {object}  VerifyTokenBatchResponse
+// @Failure      400  {object}  ErrorResponse
+// @Router       /verify/batch [POST]
+func (h TokenHandler) VerifyTokenBatch(c *gin.Context) {
+	var req VerifyTokenBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, BadRequestBodyError)
 		return
 	}
-	tokenString := reg.FindStringSubmatch(bearerToken)[1]
-	payload, err := h.tokenManager.Parse(tokenString)
-	if err != nil {
-		_ = c.AbortWithError(http.StatusUnauthorized, TokenParsingError)
+	if len(req.Tokens) > h.maxBatchSize {
+		_ = c.AbortWithError(http.StatusBadRequest, BatchSizeError)
 		return
 	}
 
-	if h.isTokenExpired(payload.ExpiredAt) {
-		_ = c.AbortWithError(http.StatusUnauthorized, TokenExpiredError)
+	payloads, errs := token.ParseBatch(h.tokenManager, req.Tokens, h.batchWorkers)
+	results := make([]VerifyTokenResult, len(req.Tokens))
+	for i, err := range errs {
+		if err == nil {
+			results[i] = VerifyTokenResult{Valid: true, Payload: payloads[i]}
+			continue
+		}
+		var validationErr *token.ValidationError
+		if errors.As(err, &validationErr) {
+			results[i].Error = validationErr.Err.Error()
+			continue
+		}
+		results[i].Error = TokenParsingError.Error()
+	}
+	c.JSON(http.StatusOK, VerifyTokenBatchResponse{Results: results})
+}
+
+func (h TokenHandler) AuthenticateToken(c *gin.Context) {
+	// The query sources are read from the URI forwarded by the proxy, as the query of the request is that of the subrequest
+	payload, err := parseRequestToken(h.tokenManager, h.extractor, c.Request.Header, h.forwardedHeaders.request(c).query())
+	if err != nil {
+		_ = c.AbortWithError(http.StatusUnauthorized, err)
 		return
 	}
 
@@ -184,9 +460,20 @@ func (h TokenHandler) AuthenticateToken(c *gin.Context) {
 	c.Next()
 }
 
-func (h TokenHandler) isTokenExpired(expiredAt time.Time) bool {
-	if h.validTime.Microseconds() == 0 || expiredAt.After(time.Now()) {
-		return false
+// parseRequestToken returns the payload of the token that the extractor finds in the headers or the query of the request,
+// or the reason that the token is rejected.
+func parseRequestToken(tokenMng token.Manager, extractor *token.Extractor, header http.Header, query url.Values) (*config.Payload, error) {
+	tokenString, err := extractor.Extract(header, query)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := tokenMng.Parse(tokenString)
+	if err != nil {
+		var validationErr *token.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, validationErr.Err
+		}
+		return nil, TokenParsingError
 	}
-	return true
+	return payload, nil
 }
@@ -1,20 +1,25 @@
 package handler_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/thetkpark/heimdall/cmd/heimdall/handler"
+	"github.com/thetkpark/heimdall/pkg/client"
 	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/header"
+	tokenPkg "github.com/thetkpark/heimdall/pkg/token"
 	"github.com/thetkpark/heimdall/test/mock_token"
 	"go.uber.org/zap"
 	"net/http"
 	"net/http/httptest"
-	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -27,20 +32,26 @@ var _ = Describe("TokenHandler", func() {
 		h                *handler.TokenHandler
 		handlerFunc      gin.HandlerFunc
 		mockTokenManager *mock_token.MockManager
+		mockRefreshMng   *mock_token.MockRefreshManager
 		payload          *config.Payload
+		issuers          *client.IssuerAuthenticator
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockTokenManager = mock_token.NewMockManager(mockCtrl)
-		h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, time.Hour)
+		mockRefreshMng = mock_token.NewMockRefreshManager(mockCtrl)
+		registry, err := client.NewRegistry(client.Client{ID: "backend", Issuance: &client.IssuancePolicy{Claims: []string{"roles"}}})
+		Expect(err).To(BeNil())
+		issuers = client.NewIssuerAuthenticator(registry, "admin-token")
+		h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, nil, issuers, time.Hour, 2, 2, nil, nil, handler.TraefikForwardedHeaders)
 		rec = httptest.NewRecorder()
 		c, _ = gin.CreateTestContext(rec)
 		payload = &config.Payload{
-			CustomPayload: config.CustomPayload{UserID: 99},
+			CustomPayload: config.CustomPayload{"user_id": json.Number("99")},
 			MetadataPayload: config.MetadataPayload{
-				IssuedAt:  time.Now(),
-				ExpiredAt: time.Now().Add(time.Hour),
+				IssuedAt:  config.NewNumericDate(time.Now()),
+				ExpiredAt: config.NewNumericDate(time.Now().Add(time.Hour)),
 			},
 		}
 
@@ -57,6 +68,7 @@ var _ = Describe("TokenHandler", func() {
 	Context("GenerateToken", func() {
 		BeforeEach(func() {
 			handlerFunc = h.GenerateToken
+			c.Set("caller", &client.Caller{ClientID: client.AdminClientID, Admin: true})
 		})
 
 		When("Correct request body", func() {
@@ -72,9 +84,28 @@ var _ = Describe("TokenHandler", func() {
 			})
 		})
 
+		When("Correct request body with arbitrary claims", func() {
+			BeforeEach(func() {
+				reqBody := strings.NewReader(`{"user_id": 18446744073709551615, "roles": ["admin"], "tenant": {"id": "acme"}}`)
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", reqBody)
+				mockTokenManager.EXPECT().Generate(gomock.Any()).DoAndReturn(func(payload config.Payload) (string, error) {
+					Expect(payload.CustomPayload).To(Equal(config.CustomPayload{
+						"user_id": json.Number("18446744073709551615"),
+						"roles":   []interface{}{"admin"},
+						"tenant":  map[string]interface{}{"id": "acme"},
+					}))
+					return "token", nil
+				}).Times(1)
+			})
+
+			It("should return 201 with token", func() {
+				Expect(rec.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
 		When("Incorrect request body", func() {
 			BeforeEach(func() {
-				reqBody := strings.NewReader(`{"non-user-id": true}`)
+				reqBody := strings.NewReader(`["user_id", 99]`)
 				c.Request, _ = http.NewRequest(http.MethodPost, "/", reqBody)
 			})
 
@@ -84,6 +115,46 @@ var _ = Describe("TokenHandler", func() {
 			})
 		})
 
+		When("Claims are rejected", func() {
+			var claimsErr error
+
+			BeforeEach(func() {
+				reqBody := strings.NewReader(`{"non-user-id": true}`)
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", reqBody)
+				claimsErr = &tokenPkg.ClaimsError{Err: errors.New("missing properties: 'user_id'")}
+				mockTokenManager.EXPECT().Generate(gomock.Any()).Return("", claimsErr).Times(1)
+			})
+
+			It("should return 400 with the reason", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Err).To(Equal(claimsErr))
+			})
+		})
+
+		When("Caller is not allowed to issue the claims", func() {
+			BeforeEach(func() {
+				c.Set("caller", &client.Caller{ClientID: "backend", Policy: &client.IssuancePolicy{Claims: []string{"roles"}}})
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"user_id": 99, "tenant": "acme"}`))
+			})
+
+			It("should return 403", func() {
+				Expect(rec.Code).To(Equal(http.StatusForbidden))
+				Expect(c.Errors.Last().Err).To(MatchError(client.ClaimForbiddenError))
+			})
+		})
+
+		When("Caller is missing from context", func() {
+			BeforeEach(func() {
+				c, _ = gin.CreateTestContext(rec)
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"user_id": 99}`))
+			})
+
+			It("should return 500", func() {
+				Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+				Expect(c.Errors.Last().Err).To(Equal(handler.GetCallerFromContextError))
+			})
+		})
+
 		When("Token generation error", func() {
 			BeforeEach(func() {
 				reqBody := strings.NewReader(`{"user_id": 99}`)
@@ -96,6 +167,313 @@ var _ = Describe("TokenHandler", func() {
 				Expect(c.Errors.Last().Err).To(Equal(handler.TokenGenerationError))
 			})
 		})
+
+		When("Refresh tokens are enabled", func() {
+			BeforeEach(func() {
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, mockRefreshMng, issuers, time.Hour, 2, 2, nil, nil, handler.TraefikForwardedHeaders)
+				handlerFunc = h.GenerateToken
+				reqBody := strings.NewReader(`{"user_id": 99}`)
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", reqBody)
+				mockRefreshMng.EXPECT().Generate(gomock.Any()).Return(&tokenPkg.TokenPair{AccessToken: "token", RefreshToken: "refresh"}, nil).Times(1)
+			})
+
+			It("should return 201 with token and refresh token", func() {
+				Expect(rec.Code).To(Equal(http.StatusCreated))
+				Expect(rec.Body.String()).To(Equal(`{"token":"token","refresh_token":"refresh"}`))
+			})
+		})
+
+		When("Refresh tokens are enabled and the caller has max TTL", func() {
+			BeforeEach(func() {
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, mockRefreshMng, issuers, time.Hour, 2, 2, nil, nil, handler.TraefikForwardedHeaders)
+				handlerFunc = h.GenerateToken
+				c.Set("caller", &client.Caller{ClientID: "backend", Policy: &client.IssuancePolicy{MaxTTL: 3600}})
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"user_id": 99}`))
+				mockTokenManager.EXPECT().Generate(gomock.Any()).Return("token", nil).Times(1)
+			})
+
+			It("should return 201 without refresh token", func() {
+				Expect(rec.Code).To(Equal(http.StatusCreated))
+				Expect(rec.Body.String()).To(Equal(`{"token":"token"}`))
+			})
+		})
+	})
+
+	Context("GenerateTokenBatch", func() {
+		BeforeEach(func() {
+			handlerFunc = h.GenerateTokenBatch
+			c.Set("caller", &client.Caller{ClientID: client.AdminClientID, Admin: true})
+		})
+
+		When("Payloads are valid", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"user_id": 1}, {"user_id": 2}]`))
+				mockTokenManager.EXPECT().GenerateBatch(gomock.Len(2)).Return([]string{"token1", "token2"}, []error{nil, nil}).Times(1)
+			})
+
+			It("should return 200 with the token of each payload", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(MatchJSON(`{"results": [{"token": "token1"}, {"token": "token2"}]}`))
+			})
+		})
+
+		When("A payload is rejected", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`[["user_id"], {"user_id": 2}, {"user_id": 3}]`))
+				claimsErr := &tokenPkg.ClaimsError{Err: errors.New("missing properties: 'user_id'")}
+				mockTokenManager.EXPECT().GenerateBatch(gomock.Len(2)).Return([]string{"", "token3"}, []error{claimsErr, nil}).Times(1)
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, nil, issuers, time.Hour, 3, 2, nil, nil, handler.TraefikForwardedHeaders)
+				handlerFunc = h.GenerateTokenBatch
+			})
+
+			It("should return 200 with the error of the rejected payloads", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				var res handler.BatchTokenResponse
+				Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(Succeed())
+				Expect(res.Results).To(HaveLen(3))
+				Expect(res.Results[0].Error).ToNot(BeEmpty())
+				Expect(res.Results[1].Error).To(ContainSubstring("missing properties"))
+				Expect(res.Results[2]).To(Equal(handler.BatchTokenResult{Token: "token3"}))
+			})
+		})
+
+		When("Token generation error", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"user_id": 1}]`))
+				mockTokenManager.EXPECT().GenerateBatch(gomock.Len(1)).Return([]string{""}, []error{errors.New("some error")}).Times(1)
+			})
+
+			It("should return 200 with the generation error", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(MatchJSON(`{"results": [{"error": "failed to generate token string"}]}`))
+			})
+		})
+
+		When("Caller is not allowed to issue a payload", func() {
+			BeforeEach(func() {
+				c.Set("caller", &client.Caller{ClientID: "backend", Policy: &client.IssuancePolicy{Claims: []string{"roles"}}})
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"tenant": "acme"}, {"roles": ["admin"]}]`))
+				mockTokenManager.EXPECT().GenerateBatch(gomock.Len(1)).Return([]string{"token"}, []error{nil}).Times(1)
+			})
+
+			It("should return 200 with the error of the payload", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(MatchJSON(`{"results": [{"error": "claim is not allowed for the client: tenant"}, {"token": "token"}]}`))
+			})
+		})
+
+		When("Payloads exceed the batch size", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"user_id": 1}, {"user_id": 2}, {"user_id": 3}]`))
+			})
+
+			It("should return 400", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Err).To(Equal(handler.BatchSizeError))
+			})
+		})
+
+		When("Payloads are empty", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`[]`))
+			})
+
+			It("should return 400", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Err).To(Equal(handler.BadRequestBodyError))
+			})
+		})
+
+		When("Refresh tokens are enabled", func() {
+			BeforeEach(func() {
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, mockRefreshMng, issuers, time.Hour, 2, 2, nil, nil, handler.TraefikForwardedHeaders)
+				handlerFunc = h.GenerateTokenBatch
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"user_id": 1}]`))
+				mockRefreshMng.EXPECT().GenerateBatch(gomock.Len(1)).Return([]*tokenPkg.TokenPair{{AccessToken: "token", RefreshToken: "refresh"}}, []error{nil}).Times(1)
+			})
+
+			It("should return 200 with the pairs", func() {
+				Expect(rec.Body.String()).To(MatchJSON(`{"results": [{"token": "token", "refresh_token": "refresh"}]}`))
+			})
+		})
+	})
+
+	Context("AuthenticateCaller", func() {
+		BeforeEach(func() {
+			handlerFunc = h.AuthenticateCaller
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+		})
+
+		When("Admin token is valid", func() {
+			BeforeEach(func() {
+				c.Request.Header.Set("Authorization", "Bearer admin-token")
+			})
+
+			It("should set the admin caller", func() {
+				caller, ok := c.Get("caller")
+				Expect(ok).To(BeTrue())
+				Expect(caller.(*client.Caller).Admin).To(BeTrue())
+			})
+		})
+
+		When("Client certificate is verified", func() {
+			BeforeEach(func() {
+				registry, err := client.NewRegistry(client.Client{ID: "service", CertificateSubject: "CN=service"})
+				Expect(err).To(BeNil())
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, nil, client.NewIssuerAuthenticator(registry, ""), time.Hour, 2, 2, nil, nil, handler.TraefikForwardedHeaders)
+				handlerFunc = h.AuthenticateCaller
+				c.Request.TLS = &tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "service"}}}},
+				}
+			})
+
+			It("should set the client of the certificate", func() {
+				caller, ok := c.Get("caller")
+				Expect(ok).To(BeTrue())
+				Expect(caller.(*client.Caller).ClientID).To(Equal("service"))
+			})
+		})
+
+		When("Token is invalid", func() {
+			BeforeEach(func() {
+				c.Request.Header.Set("Authorization", "Bearer wrong")
+			})
+
+			It("should return 401", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+				Expect(rec.Header().Get("WWW-Authenticate")).To(Equal("Bearer"))
+				Expect(c.Errors.Last().Err).To(Equal(handler.CallerAuthenticationError))
+			})
+		})
+
+		When("Credentials are missing", func() {
+			It("should return 401", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+				Expect(c.Errors.Last().Err).To(Equal(handler.CallerAuthenticationError))
+			})
+		})
+	})
+
+	Context("VerifyTokenBatch", func() {
+		BeforeEach(func() {
+			handlerFunc = h.VerifyTokenBatch
+		})
+
+		When("Tokens are verified", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"tokens": ["valid.token.string", "expired.token.string"]}`))
+				mockTokenManager.EXPECT().Parse("valid.token.string").Return(payload, nil).Times(1)
+				mockTokenManager.EXPECT().Parse("expired.token.string").Return(nil, &tokenPkg.ValidationError{Err: tokenPkg.TokenExpiredError}).Times(1)
+			})
+
+			It("should return 200 with the result of each token", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				var res handler.VerifyTokenBatchResponse
+				Expect(json.Unmarshal(rec.Body.Bytes(), &res)).To(Succeed())
+				Expect(res.Results).To(HaveLen(2))
+				Expect(res.Results[0].Valid).To(BeTrue())
+				Expect(res.Results[0].Payload.CustomPayload).To(HaveKeyWithValue("user_id", json.Number("99")))
+				Expect(res.Results[1]).To(Equal(handler.VerifyTokenResult{Error: tokenPkg.TokenExpiredError.Error()}))
+			})
+		})
+
+		When("Token is invalid", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"tokens": ["invalid"]}`))
+				mockTokenManager.EXPECT().Parse("invalid").Return(nil, errors.New("invalid signature")).Times(1)
+			})
+
+			It("should return 200 with the parsing error", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(MatchJSON(`{"results": [{"valid": false, "error": "failed to parse token"}]}`))
+			})
+		})
+
+		When("Tokens exceed the batch size", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"tokens": ["a.b.c", "a.b.c", "a.b.c"]}`))
+			})
+
+			It("should return 400", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Err).To(Equal(handler.BatchSizeError))
+			})
+		})
+
+		When("Tokens are empty", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"tokens": []}`))
+			})
+
+			It("should return 400", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Err).To(Equal(handler.BadRequestBodyError))
+			})
+		})
+	})
+
+	Context("RefreshToken", func() {
+		BeforeEach(func() {
+			h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, mockRefreshMng, issuers, time.Hour, 2, 2, nil, nil, handler.TraefikForwardedHeaders)
+			handlerFunc = h.RefreshToken
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"refresh_token": "refresh"}`))
+		})
+
+		When("Refresh token is valid", func() {
+			BeforeEach(func() {
+				mockRefreshMng.EXPECT().Refresh(gomock.Any(), "refresh").Return(&tokenPkg.TokenPair{AccessToken: "new-token", RefreshToken: "new-refresh"}, nil).Times(1)
+			})
+
+			It("should return 200 with the new pair", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(Equal(`{"token":"new-token","refresh_token":"new-refresh"}`))
+			})
+		})
+
+		When("Refresh token is reused", func() {
+			BeforeEach(func() {
+				mockRefreshMng.EXPECT().Refresh(gomock.Any(), "refresh").Return(nil, &tokenPkg.ValidationError{Err: tokenPkg.RefreshTokenReusedError}).Times(1)
+			})
+
+			It("should return 401 with the reason", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+				Expect(c.Errors.Last().Err).To(Equal(tokenPkg.RefreshTokenReusedError))
+			})
+		})
+
+		When("Refresh token is invalid", func() {
+			BeforeEach(func() {
+				mockRefreshMng.EXPECT().Refresh(gomock.Any(), "refresh").Return(nil, errors.New("invalid signature")).Times(1)
+			})
+
+			It("should return 401", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+				Expect(c.Errors.Last().Err).To(Equal(handler.TokenParsingError))
+			})
+		})
+
+		When("Request body is incorrect", func() {
+			BeforeEach(func() {
+				c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+			})
+
+			It("should return 400", func() {
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(c.Errors.Last().Err).To(Equal(handler.BadRequestBodyError))
+			})
+		})
+
+		When("Refresh tokens are disabled", func() {
+			BeforeEach(func() {
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, nil, issuers, time.Hour, 2, 2, nil, nil, handler.TraefikForwardedHeaders)
+				handlerFunc = h.RefreshToken
+			})
+
+			It("should return 404", func() {
+				Expect(rec.Code).To(Equal(http.StatusNotFound))
+				Expect(c.Errors.Last().Err).To(Equal(handler.RefreshTokenDisabledError))
+			})
+		})
 	})
 
 	Context("Verify and parse payload to body", func() {
@@ -125,9 +503,9 @@ var _ = Describe("TokenHandler", func() {
 		})
 
 		When("Payload type casting error", func() {
-			var payload *config.CustomPayload
+			var payload config.CustomPayload
 			BeforeEach(func() {
-				payload = &config.CustomPayload{UserID: 99}
+				payload = config.CustomPayload{"user_id": json.Number("99")}
 				c.Set("payload", payload)
 			})
 
@@ -151,15 +529,48 @@ var _ = Describe("TokenHandler", func() {
 
 			It("should return 200 and set correct headers", func() {
 				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("X-USER-ID")).To(Equal("99"))
+			})
+		})
 
-				for i := 0; i < reflect.TypeOf(payload.CustomPayload).NumField(); i++ {
-					field := reflect.TypeOf(payload.CustomPayload).Field(i)
-					headerName := field.Tag.Get("header")
-					if len(headerName) > 0 {
-						val := fmt.Sprintf("%v", reflect.ValueOf(payload.CustomPayload).Field(i))
-						Expect(rec.Header().Get(headerName)).To(Equal(val))
-					}
-				}
+		When("Payload has arbitrary claims", func() {
+			BeforeEach(func() {
+				payload.CustomPayload["roles"] = []interface{}{"admin", "editor"}
+				payload.CustomPayload["tenant"] = map[string]interface{}{"id": "acme"}
+				payload.CustomPayload["email_verified"] = true
+				payload.CustomPayload["bad header"] = "ignored"
+				c.Set("payload", payload)
+			})
+
+			It("should set every claim to its header", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("X-USER-ID")).To(Equal("99"))
+				Expect(rec.Header().Get("X-ROLES")).To(Equal("admin,editor"))
+				Expect(rec.Header().Get("X-TENANT")).To(Equal(`{"id":"acme"}`))
+				Expect(rec.Header().Get("X-EMAIL-VERIFIED")).To(Equal("true"))
+				Expect(rec.Header()).To(HaveLen(4))
+			})
+		})
+
+		When("Claim headers are mapped", func() {
+			BeforeEach(func() {
+				mapper, err := header.NewMapper(header.MapperConfig{
+					Headers:      []header.Mapping{{Claim: "tenant.id", Header: "X-Tenant-Id"}, {Claim: "exp", Header: "X-Token-Expiry"}},
+					ClaimsHeader: "X-Userinfo",
+				})
+				Expect(err).To(BeNil())
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, nil, issuers, time.Hour, 2, 2, mapper, nil, handler.TraefikForwardedHeaders)
+				handlerFunc = h.ParsePayloadAndSetHeader
+				payload.CustomPayload["tenant"] = map[string]interface{}{"id": "acme"}
+				c.Set("payload", payload)
+			})
+
+			It("should only set the mapped headers", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("X-Tenant-Id")).To(Equal("acme"))
+				Expect(rec.Header().Get("X-Token-Expiry")).To(Equal(strconv.FormatInt(payload.ExpiredAt.Unix(), 10)))
+				Expect(rec.Header().Get("X-Userinfo")).ToNot(BeEmpty())
+				Expect(rec.Header().Values("X-USER-ID")).To(BeEmpty())
 			})
 		})
 
@@ -171,9 +582,9 @@ var _ = Describe("TokenHandler", func() {
 		})
 
 		When("Payload type casting error", func() {
-			var payload *config.CustomPayload
+			var payload config.CustomPayload
 			BeforeEach(func() {
-				payload = &config.CustomPayload{UserID: 99}
+				payload = config.CustomPayload{"user_id": json.Number("99")}
 				c.Set("payload", payload)
 			})
 
@@ -207,12 +618,47 @@ var _ = Describe("TokenHandler", func() {
 			})
 		})
 
+		When("Token is in a cookie of the configured sources", func() {
+			BeforeEach(func() {
+				extractor, err := tokenPkg.ParseExtractor("authorization,cookie:session,query:access_token")
+				Expect(err).To(BeNil())
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, nil, issuers, time.Hour, 2, 2, nil, extractor, handler.TraefikForwardedHeaders)
+				handlerFunc = h.AuthenticateToken
+				c.Request, _ = http.NewRequest(http.MethodGet, "/?access_token=query.valid.token", nil)
+				c.Request.AddCookie(&http.Cookie{Name: "session", Value: "cookie.valid.token"})
+				mockTokenManager.EXPECT().Parse("cookie.valid.token").Return(payload, nil).Times(1)
+			})
+
+			It("should set the payload of the first source", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				_, ok := c.Get("payload")
+				Expect(ok).To(BeTrue())
+			})
+		})
+
+		When("Token is in the query of the forwarded URI", func() {
+			BeforeEach(func() {
+				extractor, err := tokenPkg.ParseExtractor("authorization,query:access_token")
+				Expect(err).To(BeNil())
+				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, nil, issuers, time.Hour, 2, 2, nil, extractor, handler.NginxForwardedHeaders)
+				handlerFunc = h.AuthenticateToken
+				c.Request, _ = http.NewRequest(http.MethodGet, "/auth/header?access_token=subrequest.valid.token", nil)
+				c.Request.Header.Set("X-Original-URI", "/invoices?access_token=query.valid.token")
+				mockTokenManager.EXPECT().Parse("query.valid.token").Return(payload, nil).Times(1)
+			})
+
+			It("should set the payload of the forwarded token", func() {
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				_, ok := c.Get("payload")
+				Expect(ok).To(BeTrue())
+			})
+		})
+
 		When("Token is expired", func() {
 			BeforeEach(func() {
 				token := "valid.expired.token"
 				c.Request.Header.Set("Authorization", "Bearer "+token)
-				payload.ExpiredAt = time.Now().Add(-time.Hour)
-				mockTokenManager.EXPECT().Parse(token).Return(payload, nil).Times(1)
+				mockTokenManager.EXPECT().Parse(token).Return(nil, &tokenPkg.ValidationError{Err: tokenPkg.TokenExpiredError}).Times(1)
 			})
 
 			It("should return unauthorized status with error", func() {
@@ -221,23 +667,16 @@ var _ = Describe("TokenHandler", func() {
 			})
 		})
 
-		When("Token valid time is indefinite", func() {
+		When("Token audience is not accepted", func() {
 			BeforeEach(func() {
-				token := "valid.expired.token"
+				token := "valid.audience.token"
 				c.Request.Header.Set("Authorization", "Bearer "+token)
-				payload.ExpiredAt = time.Now().Add(-time.Hour)
-				mockTokenManager.EXPECT().Parse(token).Return(payload, nil).Times(1)
-				h = handler.NewTokenHandler(zap.NewNop().Sugar(), mockTokenManager, 0)
-				handlerFunc = h.AuthenticateToken
+				mockTokenManager.EXPECT().Parse(token).Return(nil, &tokenPkg.ValidationError{Err: tokenPkg.TokenAudienceError}).Times(1)
 			})
 
-			It("should set the payload properly", func() {
-				Expect(rec.Code).To(Equal(http.StatusOK))
-				settledPayloadValue, ok := c.Get("payload")
-				Expect(ok).To(BeTrue())
-				settledPayload, ok := settledPayloadValue.(*config.Payload)
-				Expect(ok).To(BeTrue())
-				Expect(&settledPayload).To(Equal(&payload))
+			It("should return unauthorized status with the validation error", func() {
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+				Expect(c.Errors.Last().Err).To(Equal(tokenPkg.TokenAudienceError))
 			})
 		})
 
@@ -7,14 +7,19 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/thetkpark/heimdall/cmd/heimdall/handler"
 	"github.com/thetkpark/heimdall/cmd/heimdall/server"
+	"github.com/thetkpark/heimdall/pkg/authz"
+	"github.com/thetkpark/heimdall/pkg/client"
 	"github.com/thetkpark/heimdall/pkg/config"
 	"github.com/thetkpark/heimdall/pkg/encryption"
+	"github.com/thetkpark/heimdall/pkg/header"
 	"github.com/thetkpark/heimdall/pkg/logger"
+	"github.com/thetkpark/heimdall/pkg/revocation"
 	"github.com/thetkpark/heimdall/pkg/signature"
 	"github.com/thetkpark/heimdall/pkg/token"
 	"log"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -29,6 +34,14 @@ import (
 // @in                          header
 // @name                        Authorization
 // @description					Bearer token that is generated by the Heimdall server.
+
+// @securityDefinitions.apikey  CallerToken
+// @in                          header
+// @name                        Authorization
+// @description					Bearer admin token, or API key of the client that issues the tokens.
+
+// @securityDefinitions.basic  ClientCredentials
+// @description                HTTP Basic authentication with the client_id and client_secret of the OAuth client.
 func main() {
 	cfg, err := config.ParseConfig()
 	if err != nil {
@@ -50,22 +63,130 @@ func main() {
 	}
 	defer sentry.Flush(3 * time.Second)
 
-	signatureManager := signature.NewJWS(cfg.JWSSecretKey)
-	tokenManager := token.NewTokenManager(signatureManager, nil)
+	signingKey, retiredKeys, err := loadSignatureKeys(cfg)
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to load signature keys", "error", err, "algorithm", cfg.JWSAlgorithm)
+	}
+	signatureManager, err := signature.NewKeyring(signingKey, retiredKeys...)
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to init signature keyring", "error", err)
+	}
+	tokenFormat, err := token.ParseFormat(cfg.TokenFormat)
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to parse token format", "error", err, "format", cfg.TokenFormat)
+	}
+	claimsSchemaData := cfg.ClaimsSchema
+	if len(claimsSchemaData) == 0 {
+		claimsSchemaData = token.DefaultClaimsSchema
+	}
+	claimsSchema, err := token.NewClaimsSchema([]byte(claimsSchemaData))
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to compile claims schema", "error", err)
+	}
+	revocationStore, err := revocation.NewStore(cfg.RevocationStore, cfg.RevocationBoltPath, cfg.RevocationRedisURL)
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to open revocation store", "error", err, "store", cfg.RevocationStore)
+	}
+	defer revocationStore.Close()
+	tokenOptions := []token.Option{
+		token.WithLeeway(cfg.TokenLeeway),
+		token.WithRevocationStore(revocationStore),
+	}
+	if tokenFormat == token.StandardFormat {
+		// Legacy tokens have neither iss nor aud, so they are only checked once every token is in standard format
+		if len(cfg.TokenIssuer) > 0 {
+			tokenOptions = append(tokenOptions, token.WithExpectedIssuer(cfg.TokenIssuer))
+		}
+		if len(cfg.TokenAudience) > 0 {
+			tokenOptions = append(tokenOptions, token.WithExpectedAudience(cfg.TokenAudience...))
+		}
+	}
+	var encryptionManager encryption.Manager
 	if len(cfg.PayloadEncryptionKey) > 0 {
-		encryptionManager, err := encryption.NewAESEncryption([]byte(cfg.PayloadEncryptionKey))
+		encryptionManager, err = encryption.NewAESEncryption([]byte(cfg.PayloadEncryptionKey))
 		if err != nil {
 			sugaredLogger.Fatalw("Failed to init AESEncryption", "error", err)
 		}
-		tokenManager.SetEncryptionManager(encryptionManager)
 	}
-	tokenHandler := handler.NewTokenHandler(sugaredLogger, tokenManager, cfg.TokenValidTime)
+	tokenManager := token.NewTokenManager(signatureManager, encryptionManager, append(tokenOptions, token.WithClaimsSchema(claimsSchema))...)
+	tokenManager.SetFormat(tokenFormat)
+	tokenManager.SetIssuer(cfg.TokenIssuer)
+	tokenManager.SetAudience(cfg.TokenAudience...)
+	// The tokens of the OAuth clients only have the claims that Heimdall sets, which are not in the claims schema of the users
+	clientTokenManager := token.NewTokenManager(signatureManager, encryptionManager, tokenOptions...)
+	clientTokenManager.SetFormat(tokenFormat)
+	clientTokenManager.SetIssuer(cfg.TokenIssuer)
+	clientTokenManager.SetAudience(cfg.TokenAudience...)
+	var refreshManager token.RefreshManager
+	if cfg.RefreshTokenValidTime > 0 {
+		refreshManager, err = token.NewRefreshManager(tokenManager, cfg.TokenValidTime, cfg.RefreshTokenValidTime)
+		if err != nil {
+			sugaredLogger.Fatalw("Failed to init refresh manager", "error", err)
+		}
+	}
+	clients, err := client.ParseRegistry([]byte(cfg.Clients))
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to parse clients", "error", err)
+	}
+	// The revoked jti are only kept for the longest lifetime of the tokens
+	if maxValidTime := cfg.MaxTokenValidTime(); maxValidTime > 0 {
+		if cfg.TokenValidTime > maxValidTime || clients.MaxTokenTTL() > maxValidTime {
+			sugaredLogger.Fatalw("TOKEN_VALID_TIME and token_ttl of the clients must not exceed TOKEN_MAX_VALID_TIME", "max_valid_time", maxValidTime)
+		}
+	}
+	issuers := client.NewIssuerAuthenticator(clients, cfg.AdminToken)
+	authorizationPolicy, err := authz.ParsePolicy([]byte(cfg.AuthorizationRules))
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to parse authorization rules", "error", err)
+	}
+	headerMapper, err := header.ParseMapper([]byte(cfg.ClaimHeaders))
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to parse claim headers", "error", err)
+	}
+	if headerMapper == nil {
+		headerMapper = header.NewDefaultMapper(claimsSchema.Properties())
+	}
+	tokenExtractor, err := token.ParseExtractor(cfg.TokenSources)
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to parse token sources", "error", err)
+	}
+	var loginURL *url.URL
+	if len(cfg.ForwardAuthLoginURL) > 0 {
+		if loginURL, err = url.Parse(cfg.ForwardAuthLoginURL); err != nil {
+			sugaredLogger.Fatalw("Failed to parse forward auth login URL", "error", err)
+		}
+	}
+	forwardedHeaders, err := handler.ParseForwardedHeaders(cfg.ForwardedHeaders)
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to parse forwarded headers", "error", err, "forwarded_headers", cfg.ForwardedHeaders)
+	}
+	tlsConfig, err := server.NewTLSConfig(cfg)
+	if err != nil {
+		sugaredLogger.Fatalw("Failed to load TLS config", "error", err)
+	}
+	tokenHandler := handler.NewTokenHandler(sugaredLogger, tokenManager, refreshManager, issuers, cfg.TokenValidTime, cfg.BatchMaxSize, cfg.BatchWorkers, headerMapper, tokenExtractor, forwardedHeaders)
+	keyHandler := handler.NewKeyHandler(signatureManager, cfg.JWKSCacheMaxAge)
+	revocationHandler := handler.NewRevocationHandler(sugaredLogger, revocationStore, cfg.RevocationRetention())
+	oauthHandler := handler.NewOAuthHandler(sugaredLogger, clientTokenManager, token.NewExchangeManager(clientTokenManager), refreshManager, revocationStore, clients, cfg.TokenLeeway, cfg.TokenValidTime)
+	discoveryHandler := handler.NewDiscoveryHandler(cfg.TokenIssuer, signatureManager, clients, cfg.JWKSCacheMaxAge)
+	authorizationHandler := handler.NewAuthorizationHandler(sugaredLogger, authorizationPolicy, forwardedHeaders)
+	forwardAuthHandler := handler.NewForwardAuthHandler(sugaredLogger, tokenManager, loginURL, cfg.ForwardAuthHeaders, headerMapper, tokenExtractor, forwardedHeaders)
 
 	ginLogger := sugaredLogger.Named("GIN")
-	ginServer := server.NewGINServer(cfg, tokenHandler)
+	ginServer, err := server.NewGINServer(cfg, tlsConfig, tokenHandler, keyHandler, revocationHandler, oauthHandler, discoveryHandler, authorizationHandler, forwardAuthHandler)
+	if err != nil {
+		ginLogger.Fatalw("Failed to set trusted proxies", "error", err, "trusted_proxies", cfg.TrustedProxies)
+	}
 	go func() {
 		ginLogger.Infof("Starting GIN server on %d", cfg.GinPort)
-		if err := ginServer.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if tlsConfig != nil {
+			// The certificate is already in the TLS config
+			err = ginServer.ListenAndServeTLS("", "")
+		} else {
+			err = ginServer.ListenAndServe()
+		}
+		if err != nil && errors.Is(err, http.ErrServerClosed) {
 			ginLogger.Infof("GIN HTTP listen error: %v\n", err)
 		}
 	}()
@@ -76,7 +197,7 @@ func main() {
 	if err != nil {
 		grpcLogger.Fatalw("Failed to listen", "error", err, "port", 5050)
 	}
-	grpcServer := server.NewGRPCServer(grpcLogger, cfg, tokenManager)
+	grpcServer := server.NewGRPCServer(grpcLogger, cfg, tlsConfig, tokenManager, refreshManager, revocationStore, issuers, authorizationPolicy, headerMapper, tokenExtractor)
 	go func() {
 		grpcLogger.Infof("Starting gRPC server on %d", cfg.GRPCPort)
 		if err := grpcServer.Serve(lis); err != nil {
@@ -84,8 +205,24 @@ func main() {
 		}
 	}()
 
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := reloadSignatureKeys(signatureManager); err != nil {
+				sugaredLogger.Errorw("Failed to reload signature keys", "error", err)
+			} else {
+				sugaredLogger.Infow("Signature keys reloaded", "kid", signatureManager.SigningKeyID())
+			}
+			if err := reloadAuthorizationPolicy(authorizationPolicy); err != nil {
+				sugaredLogger.Errorw("Failed to reload authorization rules", "error", err)
+			} else {
+				sugaredLogger.Info("Authorization rules reloaded")
+			}
+		}
+	}()
 	<-quit
 	sugaredLogger.Info("SIG received, shutting down server...")
 
@@ -99,3 +236,45 @@ func main() {
 
 	sugaredLogger.Info("Server exiting")
 }
+
+func loadSignatureKeys(cfg *config.Config) (signature.Key, []signature.Key, error) {
+	material := []byte(cfg.JWSSecretKey)
+	if len(cfg.JWSPrivateKey) > 0 {
+		material = []byte(cfg.JWSPrivateKey)
+	}
+	signingKey, err := signature.NewKey(cfg.JWSKeyID, cfg.JWSAlgorithm, material)
+	if err != nil {
+		return signature.Key{}, nil, err
+	}
+
+	var retiredKeys []signature.Key
+	if len(cfg.JWSRetiredKeys) > 0 {
+		retiredKeys, err = signature.ParseKeySet([]byte(cfg.JWSRetiredKeys))
+		if err != nil {
+			return signature.Key{}, nil, err
+		}
+	}
+	return signingKey, retiredKeys, nil
+}
+
+// reloadSignatureKeys re-reads the key files so keys can be rotated without restarting the server.
+func reloadSignatureKeys(keyring *signature.Keyring) error {
+	cfg, err := config.ParseConfig()
+	if err != nil {
+		return err
+	}
+	signingKey, retiredKeys, err := loadSignatureKeys(cfg)
+	if err != nil {
+		return err
+	}
+	return keyring.Reload(signingKey, retiredKeys...)
+}
+
+// reloadAuthorizationPolicy re-reads the rules file, keeping the current rules if the new ones are invalid.
+func reloadAuthorizationPolicy(policy *authz.Policy) error {
+	cfg, err := config.ParseConfig()
+	if err != nil {
+		return err
+	}
+	return policy.ReloadData([]byte(cfg.AuthorizationRules))
+}
@@ -57,15 +57,128 @@ func (m *GenerateTokenRequest) validate(all bool) error {
 
 	var errors []error
 
-	if m.GetUserID() < 1 {
-		err := GenerateTokenRequestValidationError{
-			field:  "UserID",
-			reason: "value must be greater than or equal to 1",
+	// no validation rules for UserID
+
+	if all {
+		switch v := interface{}(m.GetCustomClaims()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GenerateTokenRequestValidationError{
+					field:  "CustomClaims",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GenerateTokenRequestValidationError{
+					field:  "CustomClaims",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
 		}
-		if !all {
-			return err
+	} else if v, ok := interface{}(m.GetCustomClaims()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GenerateTokenRequestValidationError{
+				field:  "CustomClaims",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
 		}
-		errors = append(errors, err)
+	}
+
+	if d := m.GetTTL(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = GenerateTokenRequestValidationError{
+				field:  "TTL",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			lte := time.Duration(31536000*time.Second + 0*time.Nanosecond)
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt || dur > lte {
+				err := GenerateTokenRequestValidationError{
+					field:  "TTL",
+					reason: "value must be inside range (0s, 8760h0m0s]",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	_GenerateTokenRequest_Audience_Unique := make(map[string]struct{}, len(m.GetAudience()))
+
+	for idx, item := range m.GetAudience() {
+		_, _ = idx, item
+
+		if _, exists := _GenerateTokenRequest_Audience_Unique[item]; exists {
+			err := GenerateTokenRequestValidationError{
+				field:  fmt.Sprintf("Audience[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_GenerateTokenRequest_Audience_Unique[item] = struct{}{}
+		}
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := GenerateTokenRequestValidationError{
+				field:  fmt.Sprintf("Audience[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	_GenerateTokenRequest_Scopes_Unique := make(map[string]struct{}, len(m.GetScopes()))
+
+	for idx, item := range m.GetScopes() {
+		_, _ = idx, item
+
+		if _, exists := _GenerateTokenRequest_Scopes_Unique[item]; exists {
+			err := GenerateTokenRequestValidationError{
+				field:  fmt.Sprintf("Scopes[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_GenerateTokenRequest_Scopes_Unique[item] = struct{}{}
+		}
+
+		if !_GenerateTokenRequest_Scopes_Pattern.MatchString(item) {
+			err := GenerateTokenRequestValidationError{
+				field:  fmt.Sprintf("Scopes[%v]", idx),
+				reason: "value does not match regex pattern \"^[\\\\x21\\\\x23-\\\\x5b\\\\x5d-\\\\x7e]+$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
 	}
 
 	if len(errors) > 0 {
@@ -148,6 +261,8 @@ var _ interface {
 	ErrorName() string
 } = GenerateTokenRequestValidationError{}
 
+var _GenerateTokenRequest_Scopes_Pattern = regexp.MustCompile("^[\\x21\\x23-\\x5b\\x5d-\\x7e]+$")
+
 // Validate checks the field values on TokenResponse with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -172,6 +287,8 @@ func (m *TokenResponse) validate(all bool) error {
 
 	// no validation rules for Token
 
+	// no validation rules for RefreshToken
+
 	if len(errors) > 0 {
 		return TokenResponseMultiError(errors)
 	}
@@ -249,3 +366,1687 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = TokenResponseValidationError{}
+
+// Validate checks the field values on GenerateTokensRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *GenerateTokensRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GenerateTokensRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// GenerateTokensRequestMultiError, or nil if none found.
+func (m *GenerateTokensRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GenerateTokensRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetRequests()) < 1 {
+		err := GenerateTokensRequestValidationError{
+			field:  "Requests",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GenerateTokensRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GenerateTokensRequestMultiError is an error wrapping multiple validation
+// errors returned by GenerateTokensRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GenerateTokensRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GenerateTokensRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GenerateTokensRequestMultiError) AllErrors() []error { return m }
+
+// GenerateTokensRequestValidationError is the validation error returned by
+// GenerateTokensRequest.Validate if the designated constraints aren't met.
+type GenerateTokensRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GenerateTokensRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GenerateTokensRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GenerateTokensRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GenerateTokensRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GenerateTokensRequestValidationError) ErrorName() string {
+	return "GenerateTokensRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GenerateTokensRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGenerateTokensRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GenerateTokensRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GenerateTokensRequestValidationError{}
+
+// Validate checks the field values on GenerateTokenResult with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *GenerateTokenResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GenerateTokenResult with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// GenerateTokenResultMultiError, or nil if none found.
+func (m *GenerateTokenResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GenerateTokenResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetToken()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GenerateTokenResultValidationError{
+					field:  "Token",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GenerateTokenResultValidationError{
+					field:  "Token",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetToken()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GenerateTokenResultValidationError{
+				field:  "Token",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return GenerateTokenResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// GenerateTokenResultMultiError is an error wrapping multiple validation
+// errors returned by GenerateTokenResult.ValidateAll() if the designated
+// constraints aren't met.
+type GenerateTokenResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GenerateTokenResultMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GenerateTokenResultMultiError) AllErrors() []error { return m }
+
+// GenerateTokenResultValidationError is the validation error returned by
+// GenerateTokenResult.Validate if the designated constraints aren't met.
+type GenerateTokenResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GenerateTokenResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GenerateTokenResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GenerateTokenResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GenerateTokenResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GenerateTokenResultValidationError) ErrorName() string {
+	return "GenerateTokenResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GenerateTokenResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGenerateTokenResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GenerateTokenResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GenerateTokenResultValidationError{}
+
+// Validate checks the field values on GenerateTokensResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *GenerateTokensResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GenerateTokensResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// GenerateTokensResponseMultiError, or nil if none found.
+func (m *GenerateTokensResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GenerateTokensResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GenerateTokensResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GenerateTokensResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GenerateTokensResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GenerateTokensResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GenerateTokensResponseMultiError is an error wrapping multiple validation
+// errors returned by GenerateTokensResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GenerateTokensResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GenerateTokensResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GenerateTokensResponseMultiError) AllErrors() []error { return m }
+
+// GenerateTokensResponseValidationError is the validation error returned by
+// GenerateTokensResponse.Validate if the designated constraints aren't
+// met.
+type GenerateTokensResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GenerateTokensResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GenerateTokensResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GenerateTokensResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GenerateTokensResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GenerateTokensResponseValidationError) ErrorName() string {
+	return "GenerateTokensResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GenerateTokensResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGenerateTokensResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GenerateTokensResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GenerateTokensResponseValidationError{}
+
+// Validate checks the field values on RefreshTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *RefreshTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RefreshTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// RefreshTokenRequestMultiError, or nil if none found.
+func (m *RefreshTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RefreshTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetRefreshToken()) < 1 {
+		err := RefreshTokenRequestValidationError{
+			field:  "RefreshToken",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RefreshTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RefreshTokenRequestMultiError is an error wrapping multiple validation
+// errors returned by RefreshTokenRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RefreshTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RefreshTokenRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RefreshTokenRequestMultiError) AllErrors() []error { return m }
+
+// RefreshTokenRequestValidationError is the validation error returned by
+// RefreshTokenRequest.Validate if the designated constraints aren't met.
+type RefreshTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RefreshTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RefreshTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RefreshTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RefreshTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RefreshTokenRequestValidationError) ErrorName() string {
+	return "RefreshTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RefreshTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRefreshTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RefreshTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RefreshTokenRequestValidationError{}
+
+// Validate checks the field values on VerifyTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// VerifyTokenRequestMultiError, or nil if none found.
+func (m *VerifyTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := VerifyTokenRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return VerifyTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyTokenRequestMultiError is an error wrapping multiple validation errors
+// returned by VerifyTokenRequest.ValidateAll() if the designated constraints
+// aren't met.
+type VerifyTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyTokenRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyTokenRequestMultiError) AllErrors() []error { return m }
+
+// VerifyTokenRequestValidationError is the validation error returned by
+// VerifyTokenRequest.Validate if the designated constraints aren't met.
+type VerifyTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyTokenRequestValidationError) ErrorName() string {
+	return "VerifyTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyTokenRequestValidationError{}
+
+// Validate checks the field values on VerifyTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyTokenResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// VerifyTokenResponseMultiError, or nil if none found.
+func (m *VerifyTokenResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyTokenResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Valid
+
+	// no validation rules for Reason
+
+	// no validation rules for Error
+
+	// no validation rules for ExpiredAt
+
+	if len(errors) > 0 {
+		return VerifyTokenResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyTokenResponseMultiError is an error wrapping multiple validation
+// errors returned by VerifyTokenResponse.ValidateAll() if the designated
+// constraints aren't met.
+type VerifyTokenResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyTokenResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyTokenResponseMultiError) AllErrors() []error { return m }
+
+// VerifyTokenResponseValidationError is the validation error returned by
+// VerifyTokenResponse.Validate if the designated constraints aren't met.
+type VerifyTokenResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyTokenResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyTokenResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyTokenResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyTokenResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyTokenResponseValidationError) ErrorName() string {
+	return "VerifyTokenResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyTokenResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyTokenResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyTokenResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyTokenResponseValidationError{}
+
+// Validate checks the field values on VerifyTokensRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyTokensRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyTokensRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// VerifyTokensRequestMultiError, or nil if none found.
+func (m *VerifyTokensRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyTokensRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTokens()) < 1 {
+		err := VerifyTokensRequestValidationError{
+			field:  "Tokens",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return VerifyTokensRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyTokensRequestMultiError is an error wrapping multiple validation
+// errors returned by VerifyTokensRequest.ValidateAll() if the designated
+// constraints aren't met.
+type VerifyTokensRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyTokensRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyTokensRequestMultiError) AllErrors() []error { return m }
+
+// VerifyTokensRequestValidationError is the validation error returned by
+// VerifyTokensRequest.Validate if the designated constraints aren't met.
+type VerifyTokensRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyTokensRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyTokensRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyTokensRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyTokensRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyTokensRequestValidationError) ErrorName() string {
+	return "VerifyTokensRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyTokensRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyTokensRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyTokensRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyTokensRequestValidationError{}
+
+// Validate checks the field values on VerifyTokenResult with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *VerifyTokenResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyTokenResult with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// VerifyTokenResultMultiError, or nil if none found.
+func (m *VerifyTokenResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyTokenResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Valid
+
+	// no validation rules for Reason
+
+	// no validation rules for Error
+
+	// no validation rules for ExpiredAt
+
+	if all {
+		switch v := interface{}(m.GetClaims()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, VerifyTokenResultValidationError{
+					field:  "Claims",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, VerifyTokenResultValidationError{
+					field:  "Claims",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClaims()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return VerifyTokenResultValidationError{
+				field:  "Claims",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return VerifyTokenResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyTokenResultMultiError is an error wrapping multiple validation errors
+// returned by VerifyTokenResult.ValidateAll() if the designated constraints
+// aren't met.
+type VerifyTokenResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyTokenResultMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyTokenResultMultiError) AllErrors() []error { return m }
+
+// VerifyTokenResultValidationError is the validation error returned by
+// VerifyTokenResult.Validate if the designated constraints aren't met.
+type VerifyTokenResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyTokenResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyTokenResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyTokenResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyTokenResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyTokenResultValidationError) ErrorName() string {
+	return "VerifyTokenResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyTokenResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyTokenResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyTokenResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyTokenResultValidationError{}
+
+// Validate checks the field values on VerifyTokensResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyTokensResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyTokensResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// VerifyTokensResponseMultiError, or nil if none found.
+func (m *VerifyTokensResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyTokensResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, VerifyTokensResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, VerifyTokensResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return VerifyTokensResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return VerifyTokensResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyTokensResponseMultiError is an error wrapping multiple validation
+// errors returned by VerifyTokensResponse.ValidateAll() if the designated
+// constraints aren't met.
+type VerifyTokensResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyTokensResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyTokensResponseMultiError) AllErrors() []error { return m }
+
+// VerifyTokensResponseValidationError is the validation error returned by
+// VerifyTokensResponse.Validate if the designated constraints aren't met.
+type VerifyTokensResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyTokensResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyTokensResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyTokensResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyTokensResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyTokensResponseValidationError) ErrorName() string {
+	return "VerifyTokensResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyTokensResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyTokensResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyTokensResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyTokensResponseValidationError{}
+
+// Validate checks the field values on ParseTokenRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ParseTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ParseTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// ParseTokenRequestMultiError, or nil if none found.
+func (m *ParseTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ParseTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := ParseTokenRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ParseTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ParseTokenRequestMultiError is an error wrapping multiple validation errors
+// returned by ParseTokenRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ParseTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ParseTokenRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ParseTokenRequestMultiError) AllErrors() []error { return m }
+
+// ParseTokenRequestValidationError is the validation error returned by
+// ParseTokenRequest.Validate if the designated constraints aren't met.
+type ParseTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ParseTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ParseTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ParseTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ParseTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ParseTokenRequestValidationError) ErrorName() string {
+	return "ParseTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ParseTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sParseTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ParseTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ParseTokenRequestValidationError{}
+
+// Validate checks the field values on ParseTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *ParseTokenResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ParseTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// ParseTokenResponseMultiError, or nil if none found.
+func (m *ParseTokenResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ParseTokenResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetClaims()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ParseTokenResponseValidationError{
+					field:  "Claims",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ParseTokenResponseValidationError{
+					field:  "Claims",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClaims()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ParseTokenResponseValidationError{
+				field:  "Claims",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ExpiredAt
+
+	if len(errors) > 0 {
+		return ParseTokenResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ParseTokenResponseMultiError is an error wrapping multiple validation errors
+// returned by ParseTokenResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ParseTokenResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ParseTokenResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ParseTokenResponseMultiError) AllErrors() []error { return m }
+
+// ParseTokenResponseValidationError is the validation error returned by
+// ParseTokenResponse.Validate if the designated constraints aren't met.
+type ParseTokenResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ParseTokenResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ParseTokenResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ParseTokenResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ParseTokenResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ParseTokenResponseValidationError) ErrorName() string {
+	return "ParseTokenResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ParseTokenResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sParseTokenResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ParseTokenResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ParseTokenResponseValidationError{}
+
+// Validate checks the field values on RevokeTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// RevokeTokenRequestMultiError, or nil if none found.
+func (m *RevokeTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetID()) < 1 {
+		err := RevokeTokenRequestValidationError{
+			field:  "ID",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RevokeTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeTokenRequestMultiError is an error wrapping multiple validation errors
+// returned by RevokeTokenRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RevokeTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeTokenRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeTokenRequestMultiError) AllErrors() []error { return m }
+
+// RevokeTokenRequestValidationError is the validation error returned by
+// RevokeTokenRequest.Validate if the designated constraints aren't met.
+type RevokeTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeTokenRequestValidationError) ErrorName() string {
+	return "RevokeTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeTokenRequestValidationError{}
+
+// Validate checks the field values on RevokeUserTokensRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeUserTokensRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeUserTokensRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeUserTokensRequestMultiError, or nil if none found.
+func (m *RevokeUserTokensRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeUserTokensRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserID()) < 1 {
+		err := RevokeUserTokensRequestValidationError{
+			field:  "UserID",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for IssuedBefore
+
+	if len(errors) > 0 {
+		return RevokeUserTokensRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeUserTokensRequestMultiError is an error wrapping multiple validation
+// errors returned by RevokeUserTokensRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeUserTokensRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeUserTokensRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeUserTokensRequestMultiError) AllErrors() []error { return m }
+
+// RevokeUserTokensRequestValidationError is the validation error returned by
+// RevokeUserTokensRequest.Validate if the designated constraints aren't
+// met.
+type RevokeUserTokensRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeUserTokensRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeUserTokensRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeUserTokensRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeUserTokensRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeUserTokensRequestValidationError) ErrorName() string {
+	return "RevokeUserTokensRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeUserTokensRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeUserTokensRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeUserTokensRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeUserTokensRequestValidationError{}
+
+// Validate checks the field values on RevocationResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *RevocationResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevocationResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// RevocationResponseMultiError, or nil if none found.
+func (m *RevocationResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevocationResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return RevocationResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevocationResponseMultiError is an error wrapping multiple validation errors
+// returned by RevocationResponse.ValidateAll() if the designated constraints
+// aren't met.
+type RevocationResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevocationResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevocationResponseMultiError) AllErrors() []error { return m }
+
+// RevocationResponseValidationError is the validation error returned by
+// RevocationResponse.Validate if the designated constraints aren't met.
+type RevocationResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevocationResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevocationResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevocationResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevocationResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevocationResponseValidationError) ErrorName() string {
+	return "RevocationResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevocationResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevocationResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevocationResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevocationResponseValidationError{}
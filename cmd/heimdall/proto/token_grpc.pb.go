@@ -22,7 +22,18 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TokenClient interface {
+	// GenerateToken and GenerateTokens require the "authorization: Bearer <token>" metadata of the admin token
+	// or the API key of a client, or the TLS client certificate of a client
 	GenerateToken(ctx context.Context, in *GenerateTokenRequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	// GenerateTokens generates the token of each request, signing them all with the same key
+	GenerateTokens(ctx context.Context, in *GenerateTokensRequest, opts ...grpc.CallOption) (*GenerateTokensResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	// VerifyToken reports whether the token is valid, and the reason when it is not
+	VerifyToken(ctx context.Context, in *VerifyTokenRequest, opts ...grpc.CallOption) (*VerifyTokenResponse, error)
+	// VerifyTokens verifies the tokens concurrently, and responds with the result of each
+	VerifyTokens(ctx context.Context, in *VerifyTokensRequest, opts ...grpc.CallOption) (*VerifyTokensResponse, error)
+	// ParseToken returns the claims of the valid token, or Unauthenticated with the ErrorInfo of the reason
+	ParseToken(ctx context.Context, in *ParseTokenRequest, opts ...grpc.CallOption) (*ParseTokenResponse, error)
 }
 
 type tokenClient struct {
@@ -42,11 +53,67 @@ func (c *tokenClient) GenerateToken(ctx context.Context, in *GenerateTokenReques
 	return out, nil
 }
 
+func (c *tokenClient) GenerateTokens(ctx context.Context, in *GenerateTokensRequest, opts ...grpc.CallOption) (*GenerateTokensResponse, error) {
+	out := new(GenerateTokensResponse)
+	err := c.cc.Invoke(ctx, "/Token/GenerateTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*TokenResponse, error) {
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, "/Token/RefreshToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) VerifyToken(ctx context.Context, in *VerifyTokenRequest, opts ...grpc.CallOption) (*VerifyTokenResponse, error) {
+	out := new(VerifyTokenResponse)
+	err := c.cc.Invoke(ctx, "/Token/VerifyToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) VerifyTokens(ctx context.Context, in *VerifyTokensRequest, opts ...grpc.CallOption) (*VerifyTokensResponse, error) {
+	out := new(VerifyTokensResponse)
+	err := c.cc.Invoke(ctx, "/Token/VerifyTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) ParseToken(ctx context.Context, in *ParseTokenRequest, opts ...grpc.CallOption) (*ParseTokenResponse, error) {
+	out := new(ParseTokenResponse)
+	err := c.cc.Invoke(ctx, "/Token/ParseToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TokenServer is the server API for Token service.
 // All implementations must embed UnimplementedTokenServer
 // for forward compatibility
 type TokenServer interface {
+	// GenerateToken and GenerateTokens require the "authorization: Bearer <token>" metadata of the admin token
+	// or the API key of a client, or the TLS client certificate of a client
 	GenerateToken(context.Context, *GenerateTokenRequest) (*TokenResponse, error)
+	// GenerateTokens generates the token of each request, signing them all with the same key
+	GenerateTokens(context.Context, *GenerateTokensRequest) (*GenerateTokensResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*TokenResponse, error)
+	// VerifyToken reports whether the token is valid, and the reason when it is not
+	VerifyToken(context.Context, *VerifyTokenRequest) (*VerifyTokenResponse, error)
+	// VerifyTokens verifies the tokens concurrently, and responds with the result of each
+	VerifyTokens(context.Context, *VerifyTokensRequest) (*VerifyTokensResponse, error)
+	// ParseToken returns the claims of the valid token, or Unauthenticated with the ErrorInfo of the reason
+	ParseToken(context.Context, *ParseTokenRequest) (*ParseTokenResponse, error)
 	mustEmbedUnimplementedTokenServer()
 }
 
@@ -57,6 +124,21 @@ type UnimplementedTokenServer struct {
 func (UnimplementedTokenServer) GenerateToken(context.Context, *GenerateTokenRequest) (*TokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateToken not implemented")
 }
+func (UnimplementedTokenServer) GenerateTokens(context.Context, *GenerateTokensRequest) (*GenerateTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateTokens not implemented")
+}
+func (UnimplementedTokenServer) RefreshToken(context.Context, *RefreshTokenRequest) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedTokenServer) VerifyToken(context.Context, *VerifyTokenRequest) (*VerifyTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyToken not implemented")
+}
+func (UnimplementedTokenServer) VerifyTokens(context.Context, *VerifyTokensRequest) (*VerifyTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTokens not implemented")
+}
+func (UnimplementedTokenServer) ParseToken(context.Context, *ParseTokenRequest) (*ParseTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseToken not implemented")
+}
 func (UnimplementedTokenServer) mustEmbedUnimplementedTokenServer() {}
 
 // UnsafeTokenServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +170,96 @@ func _Token_GenerateToken_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Token_GenerateTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokenServer).GenerateTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Token/GenerateTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenServer).GenerateTokens(ctx, req.(*GenerateTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Token_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokenServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Token/RefreshToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Token_VerifyToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokenServer).VerifyToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Token/VerifyToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenServer).VerifyToken(ctx, req.(*VerifyTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Token_VerifyTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokenServer).VerifyTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Token/VerifyTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenServer).VerifyTokens(ctx, req.(*VerifyTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Token_ParseToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokenServer).ParseToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Token/ParseToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenServer).ParseToken(ctx, req.(*ParseTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Token_ServiceDesc is the grpc.ServiceDesc for Token service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,6 +271,148 @@ var Token_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateToken",
 			Handler:    _Token_GenerateToken_Handler,
 		},
+		{
+			MethodName: "GenerateTokens",
+			Handler:    _Token_GenerateTokens_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _Token_RefreshToken_Handler,
+		},
+		{
+			MethodName: "VerifyToken",
+			Handler:    _Token_VerifyToken_Handler,
+		},
+		{
+			MethodName: "VerifyTokens",
+			Handler:    _Token_VerifyTokens_Handler,
+		},
+		{
+			MethodName: "ParseToken",
+			Handler:    _Token_ParseToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "token.proto",
+}
+
+// RevocationClient is the client API for Revocation service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RevocationClient interface {
+	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevocationResponse, error)
+	RevokeUserTokens(ctx context.Context, in *RevokeUserTokensRequest, opts ...grpc.CallOption) (*RevocationResponse, error)
+}
+
+type revocationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRevocationClient(cc grpc.ClientConnInterface) RevocationClient {
+	return &revocationClient{cc}
+}
+
+func (c *revocationClient) RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevocationResponse, error) {
+	out := new(RevocationResponse)
+	err := c.cc.Invoke(ctx, "/Revocation/RevokeToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *revocationClient) RevokeUserTokens(ctx context.Context, in *RevokeUserTokensRequest, opts ...grpc.CallOption) (*RevocationResponse, error) {
+	out := new(RevocationResponse)
+	err := c.cc.Invoke(ctx, "/Revocation/RevokeUserTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RevocationServer is the server API for Revocation service.
+// All implementations must embed UnimplementedRevocationServer
+// for forward compatibility
+type RevocationServer interface {
+	RevokeToken(context.Context, *RevokeTokenRequest) (*RevocationResponse, error)
+	RevokeUserTokens(context.Context, *RevokeUserTokensRequest) (*RevocationResponse, error)
+	mustEmbedUnimplementedRevocationServer()
+}
+
+// UnimplementedRevocationServer must be embedded to have forward compatible implementations.
+type UnimplementedRevocationServer struct {
+}
+
+func (UnimplementedRevocationServer) RevokeToken(context.Context, *RevokeTokenRequest) (*RevocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeToken not implemented")
+}
+func (UnimplementedRevocationServer) RevokeUserTokens(context.Context, *RevokeUserTokensRequest) (*RevocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserTokens not implemented")
+}
+func (UnimplementedRevocationServer) mustEmbedUnimplementedRevocationServer() {}
+
+// UnsafeRevocationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RevocationServer will
+// result in compilation errors.
+type UnsafeRevocationServer interface {
+	mustEmbedUnimplementedRevocationServer()
+}
+
+func RegisterRevocationServer(s grpc.ServiceRegistrar, srv RevocationServer) {
+	s.RegisterService(&Revocation_ServiceDesc, srv)
+}
+
+func _Revocation_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RevocationServer).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Revocation/RevokeToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RevocationServer).RevokeToken(ctx, req.(*RevokeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Revocation_RevokeUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUserTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RevocationServer).RevokeUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Revocation/RevokeUserTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RevocationServer).RevokeUserTokens(ctx, req.(*RevokeUserTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Revocation_ServiceDesc is the grpc.ServiceDesc for Revocation service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Revocation_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Revocation",
+	HandlerType: (*RevocationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RevokeToken",
+			Handler:    _Revocation_RevokeToken_Handler,
+		},
+		{
+			MethodName: "RevokeUserTokens",
+			Handler:    _Revocation_RevokeUserTokens_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "token.proto",
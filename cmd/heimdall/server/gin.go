@@ -1,6 +1,7 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	sentrygin "github.com/getsentry/sentry-go/gin"
 	"github.com/gin-gonic/gin"
@@ -13,9 +14,13 @@ import (
 	"time"
 )
 
-func NewGINServer(cfg *config.Config, tokenHandler *handler.TokenHandler) *http.Server {
+func NewGINServer(cfg *config.Config, tlsConfig *tls.Config, tokenHandler *handler.TokenHandler, keyHandler *handler.KeyHandler, revocationHandler *handler.RevocationHandler, oauthHandler *handler.OAuthHandler, discoveryHandler *handler.DiscoveryHandler, authorizationHandler *handler.AuthorizationHandler, forwardAuthHandler *handler.ForwardAuthHandler) (*http.Server, error) {
 	gin.SetMode(cfg.GinMode)
 	router := gin.Default()
+	// The client IP is only read from X-Forwarded-For and X-Real-IP of the trusted proxies, so the clients cannot spoof it
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, err
+	}
 	router.Use(sentrygin.New(sentrygin.Options{
 		Repanic: true,
 	}))
@@ -27,15 +32,28 @@ func NewGINServer(cfg *config.Config, tokenHandler *handler.TokenHandler) *http.
 			"timestamp": time.Now(),
 		})
 	})
-	router.GET("/auth/body", tokenHandler.AuthenticateToken, tokenHandler.ParsePayload)
-	router.GET("/auth/header", tokenHandler.AuthenticateToken, tokenHandler.ParsePayloadAndSetHeader)
-	router.POST("/generate", tokenHandler.GenerateToken)
+	router.GET("/auth/body", tokenHandler.AuthenticateToken, authorizationHandler.AuthorizeRequest, tokenHandler.ParsePayload)
+	router.GET("/auth/header", tokenHandler.AuthenticateToken, authorizationHandler.AuthorizeRequest, tokenHandler.ParsePayloadAndSetHeader)
+	router.Any("/auth/forward", forwardAuthHandler.AuthenticateRequest, authorizationHandler.AuthorizeRequest, forwardAuthHandler.ForwardAuth)
+	router.POST("/generate", tokenHandler.AuthenticateCaller, tokenHandler.GenerateToken)
+	router.POST("/generate/batch", tokenHandler.AuthenticateCaller, tokenHandler.GenerateTokenBatch)
+	router.POST("/verify/batch", tokenHandler.VerifyTokenBatch)
+	router.POST("/refresh", tokenHandler.RefreshToken)
+	router.POST("/revocations/tokens", tokenHandler.AuthenticateCaller, revocationHandler.RevokeToken)
+	router.POST("/revocations/users/:user_id", tokenHandler.AuthenticateCaller, revocationHandler.RevokeUserTokens)
+	router.POST("/oauth/token", oauthHandler.AuthenticateClient, oauthHandler.IssueToken)
+	router.POST("/revoke", oauthHandler.AuthenticateClient, oauthHandler.RevokeToken)
+	router.POST("/introspect", oauthHandler.AuthenticateClient, oauthHandler.IntrospectToken)
+	router.GET("/.well-known/jwks.json", keyHandler.GetJWKS)
+	router.GET("/.well-known/openid-configuration", discoveryHandler.GetDiscoveryDocument)
+	router.GET("/.well-known/oauth-authorization-server", discoveryHandler.GetDiscoveryDocument)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%d", cfg.GinPort),
-		Handler: router,
+		Addr:      fmt.Sprintf(":%d", cfg.GinPort),
+		Handler:   router,
+		TLSConfig: tlsConfig,
 	}
 
-	return httpServer
+	return httpServer, nil
 }
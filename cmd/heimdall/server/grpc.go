@@ -1,17 +1,32 @@
 package server
 
 import (
+	"crypto/tls"
 	grpc2 "github.com/thetkpark/heimdall/cmd/heimdall/grpc"
 	pb "github.com/thetkpark/heimdall/cmd/heimdall/proto"
+	authv3 "github.com/thetkpark/heimdall/cmd/heimdall/proto/envoy/service/auth/v3"
+	"github.com/thetkpark/heimdall/pkg/authz"
+	"github.com/thetkpark/heimdall/pkg/client"
 	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/header"
+	"github.com/thetkpark/heimdall/pkg/revocation"
 	"github.com/thetkpark/heimdall/pkg/token"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-func NewGRPCServer(logger *zap.SugaredLogger, cfg *config.Config, tokenMng token.Manager) *grpc.Server {
-	grpcServer := grpc.NewServer()
-	grpcTokenServer := grpc2.NewTokenServer(logger, tokenMng, cfg.TokenValidTime)
+func NewGRPCServer(logger *zap.SugaredLogger, cfg *config.Config, tlsConfig *tls.Config, tokenMng token.Manager, refreshMng token.RefreshManager, revocationStore revocation.Store, issuers *client.IssuerAuthenticator, policy *authz.Policy, headerMapper *header.Mapper, extractor *token.Extractor) *grpc.Server {
+	var options []grpc.ServerOption
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	grpcServer := grpc.NewServer(options...)
+	grpcTokenServer := grpc2.NewTokenServer(logger, tokenMng, refreshMng, issuers, cfg.TokenValidTime, cfg.MaxTokenValidTime(), cfg.BatchMaxSize, cfg.BatchWorkers)
 	pb.RegisterTokenServer(grpcServer, grpcTokenServer)
+	grpcRevocationServer := grpc2.NewRevocationServer(logger, revocationStore, issuers, cfg.RevocationRetention())
+	pb.RegisterRevocationServer(grpcServer, grpcRevocationServer)
+	grpcAuthorizationServer := grpc2.NewAuthorizationServer(logger, tokenMng, policy, headerMapper, extractor)
+	authv3.RegisterAuthorizationServer(grpcServer, grpcAuthorizationServer)
 	return grpcServer
 }
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"github.com/thetkpark/heimdall/pkg/config"
+)
+
+var (
+	TLSCertificateError = errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	TLSClientCAError    = errors.New("TLS_CLIENT_CA_FILE has no certificate")
+)
+
+// NewTLSConfig returns the TLS config of the servers, or nil when TLS is disabled.
+// Client certificates are verified against TLS_CLIENT_CA_FILE when they are given, so that clients can authenticate with them.
+func NewTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	if len(cfg.TLSCertificate) == 0 && len(cfg.TLSPrivateKey) == 0 {
+		if len(cfg.TLSClientCA) > 0 {
+			return nil, TLSCertificateError
+		}
+		return nil, nil
+	}
+	if len(cfg.TLSCertificate) == 0 || len(cfg.TLSPrivateKey) == 0 {
+		return nil, TLSCertificateError
+	}
+	certificate, err := tls.X509KeyPair([]byte(cfg.TLSCertificate), []byte(cfg.TLSPrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(cfg.TLSClientCA) > 0 {
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM([]byte(cfg.TLSClientCA)) {
+			return nil, TLSClientCAError
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsConfig, nil
+}
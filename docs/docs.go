@@ -16,25 +16,676 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "JSON Web Key Set (RFC 7517) of the signing and retired keys. Shared HMAC secrets are never published.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "key"
+                ],
+                "summary": "Get the public keys for verifying tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/.well-known/openid-configuration": {
+            "get": {
+                "description": "The issuer, the endpoints and the capabilities of this instance. It is also served as the RFC 8414 authorization server metadata.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "key"
+                ],
+                "summary": "Get the OpenID Connect discovery document",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DiscoveryDocument"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/body": {
             "get": {
                 "security": [
                     {
-                        "JWSToken": []
+                        "JWSToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "Verify token and parse payload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Method of the original request, or X-Original-Method",
+                        "name": "X-Forwarded-Method",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URI of the original request, or X-Original-URI",
+                        "name": "X-Forwarded-Uri",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/config.Payload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/forward": {
+            "get": {
+                "security": [
+                    {
+                        "JWSToken": []
+                    }
+                ],
+                "description": "Accepts any method. The claims are set to the headers of /auth/header, limited to FORWARD_AUTH_RESPONSE_HEADERS when set.\nBrowsers without a valid token are redirected to FORWARD_AUTH_LOGIN_URL when set.",
+                "tags": [
+                    "token"
+                ],
+                "summary": "Authenticate the request forwarded by nginx, Traefik or Caddy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Method of the original request, or X-Original-Method with FORWARDED_HEADERS=nginx",
+                        "name": "X-Forwarded-Method",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheme of the original request",
+                        "name": "X-Forwarded-Proto",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Host of the original request",
+                        "name": "X-Forwarded-Host",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URI of the original request, or X-Original-URI with FORWARDED_HEADERS=nginx",
+                        "name": "X-Forwarded-Uri",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL of the original request with FORWARDED_HEADERS=nginx",
+                        "name": "X-Original-URL",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "302": {
+                        "description": "Found"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "JWSToken": []
+                    }
+                ],
+                "description": "Accepts any method. The claims are set to the headers of /auth/header, limited to FORWARD_AUTH_RESPONSE_HEADERS when set.\nBrowsers without a valid token are redirected to FORWARD_AUTH_LOGIN_URL when set.",
+                "tags": [
+                    "token"
+                ],
+                "summary": "Authenticate the request forwarded by nginx, Traefik or Caddy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Method of the original request, or X-Original-Method with FORWARDED_HEADERS=nginx",
+                        "name": "X-Forwarded-Method",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheme of the original request",
+                        "name": "X-Forwarded-Proto",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Host of the original request",
+                        "name": "X-Forwarded-Host",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URI of the original request, or X-Original-URI with FORWARDED_HEADERS=nginx",
+                        "name": "X-Forwarded-Uri",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL of the original request with FORWARDED_HEADERS=nginx",
+                        "name": "X-Original-URL",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "302": {
+                        "description": "Found"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/header": {
+            "get": {
+                "security": [
+                    {
+                        "JWSToken": []
+                    }
+                ],
+                "description": "Every custom claim is set to the X-\u003cCLAIM-NAME\u003e header, e.g. user_id to X-USER-ID, unless CLAIM_HEADERS_FILE maps the claims",
+                "tags": [
+                    "token"
+                ],
+                "summary": "Verify token and set custom payload to header",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Method of the original request, or X-Original-Method",
+                        "name": "X-Forwarded-Method",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URI of the original request, or X-Original-URI",
+                        "name": "X-Forwarded-Uri",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/generate": {
+            "post": {
+                "security": [
+                    {
+                        "CallerToken": []
+                    }
+                ],
+                "description": "The refresh token is only returned when refresh tokens are enabled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "Generate token with the payload",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/config.CustomPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/generate/batch": {
+            "post": {
+                "security": [
+                    {
+                        "CallerToken": []
+                    }
+                ],
+                "description": "Every token of the batch is signed with the same key. The results are in the order of the payloads, with the error of each payload that fails.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "Generate the token of each payload",
+                "parameters": [
+                    {
+                        "description": "Payloads",
+                        "name": "payloads",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/config.CustomPayload"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BatchTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/introspect": {
+            "post": {
+                "security": [
+                    {
+                        "ClientCredentials": []
+                    }
+                ],
+                "description": "Responds the claims of the token along with \"active\": true, or only \"active\": false if the token is invalid, expired or revoked. Public clients are not allowed.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Introspect the token (RFC 7662)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "access_token",
+                            "refresh_token"
+                        ],
+                        "type": "string",
+                        "description": "Token type hint",
+                        "name": "token_type_hint",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IntrospectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/token": {
+            "post": {
+                "security": [
+                    {
+                        "ClientCredentials": []
+                    }
+                ],
+                "description": "The client_credentials grant issues the token of the client, and the token exchange grant issues the token that the actor, or else the client, acts on behalf of the subject of the subject token. Neither is allowed for public clients. The client_credentials scopes and the audiences default to every one that the client may request. The exchanged scopes default to the scopes of the subject token and must be a subset of them, so the scope is rejected if the subject token has none.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Issue an access token to the client (RFC 6749, RFC 8693)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "client_credentials",
+                            "urn:ietf:params:oauth:grant-type:token-exchange"
+                        ],
+                        "type": "string",
+                        "description": "Grant type",
+                        "name": "grant_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Space separated scopes",
+                        "name": "scope",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Audiences",
+                        "name": "audience",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the subject, required by token exchange",
+                        "name": "subject_token",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "urn:ietf:params:oauth:token-type:access_token",
+                            "urn:ietf:params:oauth:token-type:jwt"
+                        ],
+                        "type": "string",
+                        "description": "Type of the subject token, required by token exchange",
+                        "name": "subject_token_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the actor",
+                        "name": "actor_token",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "urn:ietf:params:oauth:token-type:access_token",
+                            "urn:ietf:params:oauth:token-type:jwt"
+                        ],
+                        "type": "string",
+                        "description": "Type of the actor token, required with the actor token",
+                        "name": "actor_token_type",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "urn:ietf:params:oauth:token-type:access_token",
+                            "urn:ietf:params:oauth:token-type:jwt"
+                        ],
+                        "type": "string",
+                        "description": "Type of the issued token",
+                        "name": "requested_token_type",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AccessTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "description": "Each refresh token can only be used once. Using it again revokes every token issued by refreshing it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "Exchange the refresh token for a new access token and refresh token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/revocations/tokens": {
+            "post": {
+                "security": [
+                    {
+                        "CallerToken": []
                     }
                 ],
-                "produces": [
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "token"
+                    "revocation"
+                ],
+                "summary": "Revoke the token of the jti",
+                "parameters": [
+                    {
+                        "description": "Token ID",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RevokeTokenRequest"
+                        }
+                    }
                 ],
-                "summary": "Verify token and parse payload",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/config.Payload"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
@@ -52,20 +703,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/header": {
-            "get": {
+        "/revocations/users/{user_id}": {
+            "post": {
                 "security": [
                     {
-                        "JWSToken": []
+                        "CallerToken": []
                     }
                 ],
+                "description": "The user is the \"sub\" claim of the token, or the user_id claim of legacy tokens. issued_before defaults to now.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "token"
+                    "revocation"
+                ],
+                "summary": "Revoke every token of the user issued before the time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Issued before",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RevokeUserTokensRequest"
+                        }
+                    }
                 ],
-                "summary": "Verify token and set custom payload to header",
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -82,8 +760,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/generate": {
+        "/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "ClientCredentials": []
+                    }
+                ],
+                "description": "Responds 200 even if the token is invalid, expired or already revoked. The client authenticates with HTTP Basic authentication or the client_id and client_secret parameters.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oauth"
+                ],
+                "summary": "Revoke the token (RFC 7009)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "access_token",
+                            "refresh_token"
+                        ],
+                        "type": "string",
+                        "description": "Token type hint",
+                        "name": "token_type_hint",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify/batch": {
             "post": {
+                "description": "The results are in the order of the tokens, with the payload of each valid token or the reason that it is rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -93,23 +834,23 @@ const docTemplate = `{
                 "tags": [
                     "token"
                 ],
-                "summary": "Generate token with the payload",
+                "summary": "Verify tokens and parse their payloads",
                 "parameters": [
                     {
-                        "description": "Payload",
-                        "name": "payload",
+                        "description": "Tokens",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/config.CustomPayload"
+                            "$ref": "#/definitions/handler.VerifyTokenBatchRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.TokenResponse"
+                            "$ref": "#/definitions/handler.VerifyTokenBatchResponse"
                         }
                     },
                     "400": {
@@ -117,12 +858,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
                     }
                 }
             }
@@ -131,29 +866,147 @@ const docTemplate = `{
     "definitions": {
         "config.CustomPayload": {
             "type": "object",
-            "required": [
-                "user_id"
-            ],
+            "additionalProperties": true
+        },
+        "config.Payload": {
+            "type": "object",
             "properties": {
-                "user_id": {
+                "aud": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exp": {
+                    "type": "integer"
+                },
+                "iat": {
+                    "type": "integer"
+                },
+                "iss": {
+                    "type": "string"
+                },
+                "jti": {
+                    "type": "string"
+                },
+                "nbf": {
                     "type": "integer"
+                },
+                "sub": {
+                    "type": "string"
                 }
             }
         },
-        "config.Payload": {
+        "handler.AccessTokenResponse": {
             "type": "object",
-            "required": [
-                "user_id"
-            ],
             "properties": {
-                "expired_at": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "issued_token_type": {
                     "type": "string"
                 },
-                "issued_at": {
+                "scope": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BatchTokenResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BatchTokenResult"
+                    }
+                }
+            }
+        },
+        "handler.BatchTokenResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.DiscoveryDocument": {
+            "type": "object",
+            "properties": {
+                "grant_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id_token_signing_alg_values_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "introspection_endpoint": {
+                    "type": "string"
+                },
+                "introspection_endpoint_auth_methods_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "jwks_uri": {
+                    "type": "string"
+                },
+                "response_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "revocation_endpoint": {
+                    "type": "string"
+                },
+                "revocation_endpoint_auth_methods_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scopes_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "subject_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_endpoint": {
+                    "type": "string"
+                },
+                "token_endpoint_auth_methods_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -165,16 +1018,108 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.IntrospectionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RevokeTokenRequest": {
+            "type": "object",
+            "required": [
+                "jti"
+            ],
+            "properties": {
+                "jti": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RevokeUserTokensRequest": {
+            "type": "object",
+            "properties": {
+                "issued_before": {
+                    "type": "integer"
+                }
+            }
+        },
         "handler.TokenResponse": {
             "type": "object",
             "properties": {
+                "refresh_token": {
+                    "type": "string"
+                },
                 "token": {
                     "type": "string"
                 }
             }
+        },
+        "handler.VerifyTokenBatchRequest": {
+            "type": "object",
+            "required": [
+                "tokens"
+            ],
+            "properties": {
+                "tokens": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.VerifyTokenBatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.VerifyTokenResult"
+                    }
+                }
+            }
+        },
+        "handler.VerifyTokenResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "payload": {
+                    "$ref": "#/definitions/config.Payload"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "CallerToken": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
+        "ClientCredentials": {
+            "type": "basic"
+        },
         "JWSToken": {
             "type": "apiKey",
             "name": "Authorization",
@@ -190,7 +1135,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "",
 	Schemes:          []string{},
 	Title:            "Heimdall HTTP API",
-	Description:      "This is a Heimdall HTTP API for issueing and verifying tokens.",
+	Description:      "HTTP Basic authentication with the client_id and client_secret of the OAuth client.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 }
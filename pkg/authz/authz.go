@@ -0,0 +1,262 @@
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/cel-go/cel"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	InvalidRuleError       = errors.New("invalid authorization rule")
+	InsufficientScopeError = errors.New("insufficient scope")
+	InsufficientRoleError  = errors.New("insufficient role")
+	ConditionFailedError   = errors.New("policy condition is not satisfied")
+	MissingPathError       = errors.New("missing request path")
+)
+
+// RolesClaim is the custom claim of the roles, a string or an array of strings.
+const RolesClaim = "roles"
+
+// wildcardSuffix at the end of a path pattern matches the rest of the path.
+const wildcardSuffix = "/**"
+
+// Rule requires the scopes and the roles of the requests that it matches.
+type Rule struct {
+	// Methods are the methods of the requests, or every method when it is empty. GET also matches HEAD.
+	Methods []string `json:"methods,omitempty"`
+	// Path is the path.Match pattern of the request paths, and "/**" at its end matches every path below it.
+	Path string `json:"path"`
+	// Scopes must all be in the scope claim.
+	Scopes []string `json:"scopes,omitempty"`
+	// Roles must have at least one in the roles claim when it is not empty.
+	Roles []string `json:"roles,omitempty"`
+	// Condition is the CEL expression of the claims and the request that must evaluate to true when it is not empty.
+	// It is type checked against the declarations of the variables when the rule is loaded.
+	Condition string `json:"condition,omitempty"`
+
+	program cel.Program
+}
+
+// Variables of the conditions
+const (
+	ClaimsVariable  = "claims"
+	RequestVariable = "request"
+)
+
+// Request is the attributes of the original request that the rules are matched and evaluated against.
+type Request struct {
+	Method string
+	// Path is the cleaned path of the request URI, or empty when the URI is unknown.
+	Path string
+	// Headers are keyed by the lower-case header names.
+	Headers map[string]string
+	IP      string
+}
+
+// NewRequest returns the request of the URI, whose path is cleaned, so "/public/../admin" is matched as "/admin".
+func NewRequest(method, requestURI string, headers map[string]string, ip string) Request {
+	req := Request{Method: method, Headers: headers, IP: ip}
+	if len(requestURI) == 0 {
+		return req
+	}
+	requestPath := requestURI
+	if u, err := url.ParseRequestURI(requestURI); err == nil {
+		requestPath = u.Path
+	} else if i := strings.IndexAny(requestURI, "?#"); i >= 0 {
+		requestPath = requestURI[:i]
+	}
+	req.Path = path.Clean("/" + requestPath)
+	return req
+}
+
+// Policy is the rules of the requests, of which the first matching one applies.
+// The rules can be reloaded while the policy is in use.
+type Policy struct {
+	rules atomic.Value
+}
+
+func NewPolicy(rules ...Rule) (*Policy, error) {
+	p := &Policy{}
+	if err := p.Reload(rules...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ParsePolicy parses the JSON array of rules. Empty data is a policy without rules.
+func ParsePolicy(data []byte) (*Policy, error) {
+	rules, err := parseRules(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewPolicy(rules...)
+}
+
+func parseRules(data []byte) ([]Rule, error) {
+	var rules []Rule
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// Reload compiles the rules and replaces the rules of the policy with them, or keeps the current rules if any is invalid.
+func (p *Policy) Reload(rules ...Rule) error {
+	compiled := make([]Rule, len(rules))
+	for i, rule := range rules {
+		if !strings.HasPrefix(rule.Path, "/") {
+			return fmt.Errorf("%w %d: path must start with /", InvalidRuleError, i)
+		}
+		if _, err := path.Match(strings.TrimSuffix(rule.Path, wildcardSuffix), ""); err != nil {
+			return fmt.Errorf("%w %d: %v", InvalidRuleError, i, err)
+		}
+		if len(rule.Condition) > 0 {
+			program, err := compileCondition(rule.Condition)
+			if err != nil {
+				return fmt.Errorf("%w %d: %v", InvalidRuleError, i, err)
+			}
+			rule.program = program
+		}
+		compiled[i] = rule
+	}
+	p.rules.Store(compiled)
+	return nil
+}
+
+// ReloadData parses the JSON array of rules and reloads the policy with them.
+func (p *Policy) ReloadData(data []byte) error {
+	rules, err := parseRules(data)
+	if err != nil {
+		return err
+	}
+	return p.Reload(rules...)
+}
+
+// Match returns the first rule of the request, or nil if no rule matches.
+// It returns MissingPathError if the policy has rules and the path of the request is unknown,
+// as the request might match any of them.
+func (p *Policy) Match(req Request) (*Rule, error) {
+	rules := p.rules.Load().([]Rule)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if len(req.Path) == 0 {
+		return nil, MissingPathError
+	}
+	for i := range rules {
+		if rules[i].matches(req.Method, req.Path) {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r Rule) matches(method, requestPath string) bool {
+	if len(r.Methods) > 0 {
+		allowed := false
+		// HEAD is the GET without the body, so it must not get around the rules of GET
+		isHead := strings.EqualFold(method, http.MethodHead)
+		for _, m := range r.Methods {
+			if strings.EqualFold(m, method) || (isHead && strings.EqualFold(m, http.MethodGet)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	if strings.HasSuffix(r.Path, wildcardSuffix) {
+		// The prefix is matched against as many segments of the path, so its patterns do not match across "/"
+		prefix := strings.TrimSuffix(r.Path, wildcardSuffix)
+		n := strings.Count(prefix, "/")
+		segments := strings.Split(requestPath, "/")
+		if len(segments) <= n {
+			return false
+		}
+		matched, _ := path.Match(prefix, strings.Join(segments[:n+1], "/"))
+		return matched
+	}
+	matched, _ := path.Match(r.Path, requestPath)
+	return matched
+}
+
+// Authorize returns InsufficientScopeError or InsufficientRoleError if the payload does not have the scopes or the roles of the rule,
+// or ConditionFailedError if the condition of the rule is not true for the payload and the request.
+func (r Rule) Authorize(req Request, payload *config.Payload) error {
+	claims := payload.CustomPayload
+	scope, _ := claims[token.ScopeClaim].(string)
+	scopes := strings.Fields(scope)
+	for _, required := range r.Scopes {
+		if !contains(scopes, required) {
+			return InsufficientScopeError
+		}
+	}
+	if len(r.Roles) > 0 && !containsAny(stringValues(claims[RolesClaim]), r.Roles) {
+		return InsufficientRoleError
+	}
+	if r.program == nil {
+		return nil
+	}
+
+	// The condition sees the registered claims along with the custom claims, as they are in the token
+	allClaims, err := payload.Claims()
+	if err != nil {
+		return err
+	}
+	satisfied, err := evalCondition(r.program, allClaims, req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ConditionFailedError, err)
+	}
+	if !satisfied {
+		return ConditionFailedError
+	}
+	return nil
+}
+
+// stringValues returns the string, or the strings of the array.
+func stringValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, element := range v {
+			if s, ok := element.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, candidate := range candidates {
+		if contains(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package authz_test
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/authz"
+	"github.com/thetkpark/heimdall/pkg/config"
+)
+
+var _ = Describe("Policy", func() {
+	var policy *authz.Policy
+
+	BeforeEach(func() {
+		var err error
+		policy, err = authz.ParsePolicy([]byte(`[
+			{"methods": ["GET"], "path": "/invoices/*", "scopes": ["invoices:read"]},
+			{"methods": ["POST", "DELETE"], "path": "/invoices/**", "scopes": ["invoices:write"]},
+			{"path": "/admin/**", "roles": ["admin", "owner"]},
+			{"path": "/tenants/*/users/**", "roles": ["tenant-admin"]}
+		]`))
+		Expect(err).To(BeNil())
+	})
+
+	When("Request matches a rule", func() {
+		It("returns the first matching rule", func() {
+			rule, err := policy.Match(authz.NewRequest("get", "/invoices/42?expand=lines", nil, ""))
+			Expect(err).To(BeNil())
+			Expect(rule).ToNot(BeNil())
+			Expect(rule.Scopes).To(Equal([]string{"invoices:read"}))
+		})
+
+		It("matches every path below the wildcard", func() {
+			Expect(policy.Match(authz.NewRequest("DELETE", "/invoices/42/lines/1", nil, ""))).ToNot(BeNil())
+			Expect(policy.Match(authz.NewRequest("GET", "/admin", nil, ""))).ToNot(BeNil())
+			Expect(policy.Match(authz.NewRequest("GET", "/tenants/acme/users/7/roles", nil, ""))).ToNot(BeNil())
+		})
+
+		It("matches HEAD with the rules of GET", func() {
+			rule, err := policy.Match(authz.NewRequest("HEAD", "/invoices/42", nil, ""))
+			Expect(err).To(BeNil())
+			Expect(rule).ToNot(BeNil())
+			Expect(rule.Scopes).To(Equal([]string{"invoices:read"}))
+		})
+
+		It("matches the cleaned path", func() {
+			rule, err := policy.Match(authz.NewRequest("GET", "/public/..%2Fadmin/users", nil, ""))
+			Expect(err).To(BeNil())
+			Expect(rule).ToNot(BeNil())
+			Expect(rule.Roles).To(Equal([]string{"admin", "owner"}))
+		})
+	})
+
+	When("Request matches no rule", func() {
+		It("returns nil", func() {
+			Expect(policy.Match(authz.NewRequest("PUT", "/invoices/42", nil, ""))).To(BeNil())
+			Expect(policy.Match(authz.NewRequest("GET", "/administrators", nil, ""))).To(BeNil())
+			Expect(policy.Match(authz.NewRequest("GET", "/tenants/acme/groups", nil, ""))).To(BeNil())
+		})
+	})
+
+	When("Request path is unknown", func() {
+		It("returns MissingPathError", func() {
+			_, err := policy.Match(authz.NewRequest("GET", "", nil, ""))
+			Expect(err).To(MatchError(authz.MissingPathError))
+		})
+
+		It("returns nil if the policy has no rules", func() {
+			empty, err := authz.ParsePolicy(nil)
+			Expect(err).To(BeNil())
+			Expect(empty.Match(authz.NewRequest("GET", "", nil, ""))).To(BeNil())
+		})
+	})
+
+	When("Rule is invalid", func() {
+		It("returns InvalidRuleError", func() {
+			_, err := authz.NewPolicy(authz.Rule{Path: "invoices"})
+			Expect(err).To(MatchError(authz.InvalidRuleError))
+			_, err = authz.NewPolicy(authz.Rule{Path: "/invoices/["})
+			Expect(err).To(MatchError(authz.InvalidRuleError))
+			_, err = authz.NewPolicy(authz.Rule{Path: "/invoices/**", Condition: "claims.tenant =="})
+			Expect(err).To(MatchError(authz.InvalidRuleError))
+		})
+	})
+
+	When("Policy is reloaded", func() {
+		It("replaces the rules", func() {
+			Expect(policy.ReloadData([]byte(`[{"path": "/reports/**", "roles": ["analyst"]}]`))).To(Succeed())
+			Expect(policy.Match(authz.NewRequest("GET", "/admin", nil, ""))).To(BeNil())
+			Expect(policy.Match(authz.NewRequest("GET", "/reports/daily", nil, ""))).ToNot(BeNil())
+		})
+
+		It("keeps the rules if the new rules are invalid", func() {
+			Expect(policy.ReloadData([]byte(`[{"path": "/reports/**", "condition": "unknown.field"}]`))).To(MatchError(authz.InvalidRuleError))
+			Expect(policy.Match(authz.NewRequest("GET", "/admin", nil, ""))).ToNot(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Rule", func() {
+	rule := authz.Rule{Path: "/**", Scopes: []string{"read", "write"}, Roles: []string{"admin", "editor"}}
+	req := authz.NewRequest("GET", "/tenants/acme/invoices", map[string]string{"x-request-id": "1"}, "10.0.0.1")
+	authorize := func(rule authz.Rule, claims config.CustomPayload) error {
+		return rule.Authorize(req, &config.Payload{CustomPayload: claims, MetadataPayload: config.MetadataPayload{Subject: "99"}})
+	}
+
+	It("authorizes the claims with every scope and any role", func() {
+		Expect(authorize(rule, config.CustomPayload{"scope": "write read", "roles": []interface{}{"viewer", "editor"}})).To(Succeed())
+		Expect(authorize(rule, config.CustomPayload{"scope": "read write", "roles": "admin"})).To(Succeed())
+	})
+
+	It("returns InsufficientScopeError without every scope", func() {
+		Expect(authorize(rule, config.CustomPayload{"scope": "read", "roles": "admin"})).To(MatchError(authz.InsufficientScopeError))
+	})
+
+	It("returns InsufficientRoleError without any role", func() {
+		Expect(authorize(rule, config.CustomPayload{"scope": "read write", "roles": []interface{}{"viewer"}})).To(MatchError(authz.InsufficientRoleError))
+	})
+
+	It("authorizes every claims without scopes and roles", func() {
+		Expect(authorize(authz.Rule{Path: "/**"}, config.CustomPayload{})).To(Succeed())
+	})
+
+	When("Rule has a condition", func() {
+		var rule *authz.Rule
+
+		BeforeEach(func() {
+			policy, err := authz.NewPolicy(authz.Rule{
+				Path:      "/tenants/*/**",
+				Condition: `claims.tenant == request.path.split('/')[2] && claims.sub == '99' && request.ip.inCIDR('10.0.0.0/8')`,
+			})
+			Expect(err).To(BeNil())
+			rule, err = policy.Match(req)
+			Expect(err).To(BeNil())
+		})
+
+		It("authorizes the claims that satisfy the condition", func() {
+			Expect(authorize(*rule, config.CustomPayload{"tenant": "acme"})).To(Succeed())
+		})
+
+		It("returns ConditionFailedError if the condition is false", func() {
+			Expect(authorize(*rule, config.CustomPayload{"tenant": "globex"})).To(MatchError(authz.ConditionFailedError))
+		})
+
+		It("returns ConditionFailedError if the condition cannot be evaluated", func() {
+			Expect(authorize(*rule, config.CustomPayload{})).To(MatchError(authz.ConditionFailedError))
+		})
+	})
+})
+
+var _ = Describe("Condition", func() {
+	req := authz.NewRequest("GET", "/tenants/acme/invoices", map[string]string{"x-api-version": "2"}, "10.1.2.3")
+	claims := config.CustomPayload{
+		"user_id": json.Number("99"),
+		"tenant":  "acme",
+		"scope":   "invoices:read invoices:write",
+		"roles":   []interface{}{"editor", "viewer"},
+		"org":     map[string]interface{}{"tier": "gold", "seats": json.Number("2.5")},
+	}
+	authorize := func(condition string) error {
+		policy, err := authz.NewPolicy(authz.Rule{Path: "/**", Condition: condition})
+		if err != nil {
+			return err
+		}
+		rule, err := policy.Match(req)
+		if err != nil {
+			return err
+		}
+		return rule.Authorize(req, &config.Payload{CustomPayload: claims, MetadataPayload: config.MetadataPayload{Subject: "99"}})
+	}
+
+	When("Condition is true", func() {
+		for _, condition := range []string{
+			`claims.tenant == request.path.split('/')[2]`,
+			`request.path.startsWith('/tenants/' + claims.tenant + '/') && claims.sub == '99'`,
+			`'editor' in claims.roles && !('admin' in claims.roles)`,
+			`claims.roles.exists(r, r == 'admin') || claims.user_id > 10`,
+			`claims.roles.filter(r, r != 'viewer').map(r, size(r)) == [6]`,
+			`has(claims.org.tier) && !has(claims.org.region)`,
+			`claims.org.seats * 2.0 == 5.0 && claims.user_id % 10 == 9`,
+			`int(request.headers['x-api-version']) >= 2`,
+			`request.ip.inCIDR('10.0.0.0/8') && !request.ip.inCIDR("192.168.0.0/16")`,
+			`request.method.matches('^(GET|HEAD)$') && claims.scope.matches(r'invoices:\w+')`,
+			`claims.missing == 'x' || true`,
+		} {
+			condition := condition
+			It("authorizes "+condition, func() {
+				Expect(authorize(condition)).To(Succeed())
+			})
+		}
+	})
+
+	When("Condition is false or cannot be evaluated", func() {
+		for _, condition := range []string{
+			`claims.tenant != request.path.split('/')[2]`,
+			`claims.region == 'eu'`,
+			`claims.roles[2] == 'admin'`,
+			`claims.user_id / 0 > 1`,
+			`claims.tenant > 1`,
+			`claims.tenant`,
+		} {
+			condition := condition
+			It("returns ConditionFailedError of "+condition, func() {
+				Expect(authorize(condition)).To(MatchError(authz.ConditionFailedError))
+			})
+		}
+	})
+
+	When("Condition is invalid", func() {
+		for _, condition := range []string{
+			`claims.tenant ==`,
+			`claims.tenant = 'acme'`,
+			`token.sub == 'admin'`,
+			`request.pth == '/admin'`,
+			`request.headers.x_api_version == 2`,
+			`claims.roles.exists(r, x == r)`,
+			`lower(claims.tenant)`,
+			`request.path.matches('[')`,
+			`request.ip.inCIDR('10.0.0.0')`,
+			`request.method + claims.tenant`,
+		} {
+			condition := condition
+			It("returns InvalidRuleError of "+condition, func() {
+				Expect(authorize(condition)).To(MatchError(authz.InvalidRuleError))
+			})
+		}
+	})
+})
@@ -0,0 +1,160 @@
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"net"
+)
+
+var NotBoolConditionError = errors.New("condition does not evaluate to bool")
+
+// The fields of the request variable
+const (
+	requestMethod  = RequestVariable + ".method"
+	requestPath    = RequestVariable + ".path"
+	requestHeaders = RequestVariable + ".headers"
+	requestIP      = RequestVariable + ".ip"
+)
+
+// conditionEnv declares the variables of the conditions, so the references to anything else fail to compile.
+// The fields of the request are declared as qualified names, so request.pth fails to compile as well.
+var conditionEnv = newConditionEnv()
+
+// newConditionEnv panics if the declarations are invalid, as they never change.
+func newConditionEnv() *cel.Env {
+	env, err := cel.NewEnv(
+		cel.Variable(ClaimsVariable, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(requestMethod, cel.StringType),
+		cel.Variable(requestPath, cel.StringType),
+		cel.Variable(requestHeaders, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(requestIP, cel.StringType),
+		ext.Strings(),
+		cel.Function("inCIDR",
+			cel.MemberOverload("string_inCIDR_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(inCIDR))),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return env
+}
+
+// compileCondition type checks the condition, which must evaluate to a bool, and compiles its literal regular expressions and CIDR blocks.
+func compileCondition(source string) (cel.Program, error) {
+	ast, issues := conditionEnv.Compile(source)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	// The claims are dynamic, so their conditions are only known to be bool when they are evaluated
+	if resultType := ast.ResultType(); resultType.GetPrimitive() != exprpb.Type_BOOL && resultType.GetDyn() == nil {
+		return nil, fmt.Errorf("%w: %s", NotBoolConditionError, ast.OutputType())
+	}
+	if err := checkCIDRLiterals(ast.Expr()); err != nil {
+		return nil, err
+	}
+	return conditionEnv.Program(ast, cel.EvalOptions(cel.OptOptimize))
+}
+
+// evalCondition evaluates the condition with the claims and the request.
+func evalCondition(program cel.Program, claims map[string]interface{}, req Request) (bool, error) {
+	headers := req.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	out, _, err := program.Eval(map[string]interface{}{
+		ClaimsVariable: claimValue(claims),
+		requestMethod:  req.Method,
+		requestPath:    req.Path,
+		requestHeaders: headers,
+		requestIP:      req.IP,
+	})
+	if err != nil {
+		return false, err
+	}
+	satisfied, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("%w: %s", NotBoolConditionError, out.Type().TypeName())
+	}
+	return satisfied, nil
+}
+
+// claimValue converts the JSON numbers of the claims to the int or the double of CEL.
+func claimValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			m[key] = claimValue(element)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, element := range v {
+			list[i] = claimValue(element)
+		}
+		return list
+	}
+	return value
+}
+
+// inCIDR reports whether the IP address string is in the CIDR block.
+func inCIDR(ip, cidr ref.Val) ref.Val {
+	_, network, err := net.ParseCIDR(string(cidr.(types.String)))
+	if err != nil {
+		return types.NewErr("invalid CIDR block %q", string(cidr.(types.String)))
+	}
+	address := net.ParseIP(string(ip.(types.String)))
+	if address == nil {
+		return types.NewErr("invalid IP address %q", string(ip.(types.String)))
+	}
+	return types.Bool(network.Contains(address))
+}
+
+// checkCIDRLiterals returns the error of the first invalid literal CIDR block of inCIDR.
+func checkCIDRLiterals(e *exprpb.Expr) error {
+	if e == nil {
+		return nil
+	}
+	var children []*exprpb.Expr
+	switch kind := e.GetExprKind().(type) {
+	case *exprpb.Expr_CallExpr:
+		call := kind.CallExpr
+		if call.GetFunction() == "inCIDR" && len(call.GetArgs()) == 1 {
+			if literal, ok := call.GetArgs()[0].GetConstExpr().GetConstantKind().(*exprpb.Constant_StringValue); ok {
+				if _, _, err := net.ParseCIDR(literal.StringValue); err != nil {
+					return err
+				}
+			}
+		}
+		children = append([]*exprpb.Expr{call.GetTarget()}, call.GetArgs()...)
+	case *exprpb.Expr_SelectExpr:
+		children = []*exprpb.Expr{kind.SelectExpr.GetOperand()}
+	case *exprpb.Expr_ListExpr:
+		children = kind.ListExpr.GetElements()
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range kind.StructExpr.GetEntries() {
+			children = append(children, entry.GetMapKey(), entry.GetValue())
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		c := kind.ComprehensionExpr
+		children = []*exprpb.Expr{c.GetIterRange(), c.GetAccuInit(), c.GetLoopCondition(), c.GetLoopStep(), c.GetResult()}
+	}
+	for _, child := range children {
+		if err := checkCIDRLiterals(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
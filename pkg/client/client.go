@@ -0,0 +1,160 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	InvalidClientError   = errors.New("invalid client credentials")
+	MissingClientIDError = errors.New("client has no client_id")
+	DuplicateClientError = errors.New("client_id is duplicated")
+	// DuplicateCredentialError is returned when clients share an API key or a certificate subject
+	DuplicateCredentialError = errors.New("client credential is duplicated")
+)
+
+// dummySecretHash is compared against when the client is unknown, so that unknown clients take as long as known ones.
+var dummySecretHash = []byte("$2a$10$MGky85Gy2TxmNGD9Ucjtl.3EEI440L9oTgRr6tyoEGOyzxUV7DrAS")
+
+// Client is a caller of the OAuth endpoints.
+type Client struct {
+	ID string `json:"client_id"`
+	// SecretHash is the bcrypt hash of the client secret. Clients without secret are public clients.
+	SecretHash string `json:"client_secret_hash,omitempty"`
+	// APIKeyHash is the hex encoded SHA-256 hash of the API key that the client authenticates with to issue tokens.
+	APIKeyHash string `json:"api_key_hash,omitempty"`
+	// CertificateSubject is the subject DN of the TLS client certificate that the client authenticates with to issue tokens.
+	CertificateSubject string `json:"tls_client_auth_subject_dn,omitempty"`
+	// Issuance is what the client may issue. Clients without it cannot issue tokens.
+	Issuance *IssuancePolicy `json:"issuance,omitempty"`
+	// Scopes are the scopes that the client may request with the client_credentials grant.
+	Scopes []string `json:"scopes,omitempty"`
+	// Audiences are the audiences that the client may request with the client_credentials grant.
+	Audiences []string `json:"audiences,omitempty"`
+	// TokenTTL is how many seconds the tokens of the client_credentials grant are valid for. Defaults to TOKEN_VALID_TIME.
+	TokenTTL int64 `json:"token_ttl,omitempty"`
+}
+
+// IsPublic reports whether the client has no secret to authenticate with.
+func (c Client) IsPublic() bool {
+	return len(c.SecretHash) == 0
+}
+
+// AllowsScope reports whether the client may request the scope.
+func (c Client) AllowsScope(scope string) bool {
+	return contains(c.Scopes, scope)
+}
+
+// AllowsAudience reports whether the client may request the audience.
+func (c Client) AllowsAudience(audience string) bool {
+	return contains(c.Audiences, audience)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry is the registered clients.
+type Registry struct {
+	clients map[string]Client
+	// apiKeys and subjects are the client IDs of the API key hashes and the certificate subjects
+	apiKeys  map[string]string
+	subjects map[string]string
+}
+
+func NewRegistry(clients ...Client) (*Registry, error) {
+	registry := &Registry{
+		clients:  make(map[string]Client, len(clients)),
+		apiKeys:  map[string]string{},
+		subjects: map[string]string{},
+	}
+	for _, client := range clients {
+		if len(client.ID) == 0 {
+			return nil, MissingClientIDError
+		}
+		if _, ok := registry.clients[client.ID]; ok {
+			return nil, DuplicateClientError
+		}
+		registry.clients[client.ID] = client
+		if len(client.APIKeyHash) > 0 {
+			apiKeyHash := strings.ToLower(client.APIKeyHash)
+			if _, ok := registry.apiKeys[apiKeyHash]; ok {
+				return nil, DuplicateCredentialError
+			}
+			registry.apiKeys[apiKeyHash] = client.ID
+		}
+		if len(client.CertificateSubject) > 0 {
+			if _, ok := registry.subjects[client.CertificateSubject]; ok {
+				return nil, DuplicateCredentialError
+			}
+			registry.subjects[client.CertificateSubject] = client.ID
+		}
+	}
+	return registry, nil
+}
+
+// ParseRegistry parses the JSON array of clients. Empty data is an empty registry.
+func ParseRegistry(data []byte) (*Registry, error) {
+	var clients []Client
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &clients); err != nil {
+			return nil, err
+		}
+	}
+	return NewRegistry(clients...)
+}
+
+// Scopes returns the sorted scopes that any client may request.
+func (r *Registry) Scopes() []string {
+	seen := map[string]bool{}
+	scopes := []string{}
+	for _, client := range r.clients {
+		for _, scope := range client.Scopes {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// MaxTokenTTL returns the longest token_ttl of the clients, or zero when no client has its own TTL.
+func (r *Registry) MaxTokenTTL() time.Duration {
+	var maxTTL int64
+	for _, client := range r.clients {
+		if client.TokenTTL > maxTTL {
+			maxTTL = client.TokenTTL
+		}
+	}
+	return time.Duration(maxTTL) * time.Second
+}
+
+// Authenticate returns the client of the id if the secret matches. Public clients must not have a secret.
+func (r *Registry) Authenticate(id, secret string) (*Client, error) {
+	client, ok := r.clients[id]
+	if !ok {
+		_ = bcrypt.CompareHashAndPassword(dummySecretHash, []byte(secret))
+		return nil, InvalidClientError
+	}
+	if client.IsPublic() {
+		if len(secret) > 0 {
+			return nil, InvalidClientError
+		}
+		return &client, nil
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(secret)); err != nil {
+		return nil, InvalidClientError
+	}
+	return &client, nil
+}
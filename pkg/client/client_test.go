@@ -0,0 +1,91 @@
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+)
+
+var _ = Describe("Registry", func() {
+	var registry *client.Registry
+
+	BeforeEach(func() {
+		secretHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+		Expect(err).To(BeNil())
+		registry, err = client.ParseRegistry([]byte(`[
+			{"client_id": "confidential", "client_secret_hash": "` + string(secretHash) + `"},
+			{"client_id": "public"}
+		]`))
+		Expect(err).To(BeNil())
+	})
+
+	It("authenticates confidential client with its secret", func() {
+		authenticatedClient, err := registry.Authenticate("confidential", "secret")
+		Expect(err).To(BeNil())
+		Expect(authenticatedClient.ID).To(Equal("confidential"))
+		Expect(authenticatedClient.IsPublic()).To(BeFalse())
+	})
+
+	It("rejects confidential client with wrong secret", func() {
+		_, err := registry.Authenticate("confidential", "wrong")
+		Expect(err).To(Equal(client.InvalidClientError))
+	})
+
+	It("rejects confidential client without secret", func() {
+		_, err := registry.Authenticate("confidential", "")
+		Expect(err).To(Equal(client.InvalidClientError))
+	})
+
+	It("authenticates public client without secret", func() {
+		authenticatedClient, err := registry.Authenticate("public", "")
+		Expect(err).To(BeNil())
+		Expect(authenticatedClient.IsPublic()).To(BeTrue())
+	})
+
+	It("rejects public client with secret", func() {
+		_, err := registry.Authenticate("public", "secret")
+		Expect(err).To(Equal(client.InvalidClientError))
+	})
+
+	It("rejects unknown client", func() {
+		_, err := registry.Authenticate("unknown", "secret")
+		Expect(err).To(Equal(client.InvalidClientError))
+	})
+
+	It("parses empty registry", func() {
+		registry, err := client.ParseRegistry(nil)
+		Expect(err).To(BeNil())
+		_, err = registry.Authenticate("", "")
+		Expect(err).To(Equal(client.InvalidClientError))
+	})
+
+	It("rejects duplicated client", func() {
+		_, err := client.ParseRegistry([]byte(`[{"client_id": "a"}, {"client_id": "a"}]`))
+		Expect(err).To(Equal(client.DuplicateClientError))
+	})
+
+	It("allows the scopes and the audiences of the client", func() {
+		registry, err := client.ParseRegistry([]byte(`[{"client_id": "service", "scopes": ["read"], "audiences": ["api"], "token_ttl": 300}]`))
+		Expect(err).To(BeNil())
+		authenticatedClient, err := registry.Authenticate("service", "")
+		Expect(err).To(BeNil())
+		Expect(authenticatedClient.AllowsScope("read")).To(BeTrue())
+		Expect(authenticatedClient.AllowsScope("write")).To(BeFalse())
+		Expect(authenticatedClient.AllowsAudience("api")).To(BeTrue())
+		Expect(authenticatedClient.TokenTTL).To(Equal(int64(300)))
+	})
+
+	It("returns the longest token TTL of the clients", func() {
+		Expect(registry.MaxTokenTTL()).To(BeZero())
+		registry, err := client.ParseRegistry([]byte(`[{"client_id": "a", "token_ttl": 300}, {"client_id": "b", "token_ttl": 600}]`))
+		Expect(err).To(BeNil())
+		Expect(registry.MaxTokenTTL()).To(Equal(10 * time.Minute))
+	})
+
+	It("rejects client without client_id", func() {
+		_, err := client.ParseRegistry([]byte(`[{"client_secret_hash": "hash"}]`))
+		Expect(err).To(Equal(client.MissingClientIDError))
+	})
+})
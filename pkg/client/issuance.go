@@ -0,0 +1,174 @@
+package client
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"strconv"
+	"time"
+)
+
+var (
+	IssuanceForbiddenError = errors.New("client is not allowed to issue tokens")
+	ClaimForbiddenError    = errors.New("claim is not allowed for the client")
+	UserIDForbiddenError   = errors.New("user_id is not allowed for the client")
+	AudienceForbiddenError = errors.New("audience is not allowed for the client")
+	TTLForbiddenError      = errors.New("token lifetime exceeds the max_ttl of the client")
+)
+
+// AdminClientID is the client ID of the caller authenticated with the admin token.
+const AdminClientID = "admin"
+
+// userIDClaim is token.UserIDClaim, which is not imported as the token package does not depend on clients.
+const userIDClaim = "user_id"
+
+// IssuancePolicy is the claims, the audiences and the lifetime that a client may issue tokens with.
+type IssuancePolicy struct {
+	// Claims are the names of the custom claims besides user_id. "*" allows every claim.
+	Claims []string `json:"claims,omitempty"`
+	// UserIDs are the ranges of the user_id claim. Every user_id is allowed when it is empty.
+	UserIDs []UserIDRange `json:"user_ids,omitempty"`
+	// Audiences are the audiences that replace the default one. "*" allows every audience.
+	Audiences []string `json:"audiences,omitempty"`
+	// MaxTTL is the longest seconds that the tokens are valid for. The lifetime is not limited when it is zero.
+	MaxTTL int64 `json:"max_ttl,omitempty"`
+}
+
+// UserIDRange is the user IDs from Min to Max, inclusive.
+type UserIDRange struct {
+	Min uint64 `json:"min"`
+	Max uint64 `json:"max"`
+}
+
+// Allows returns the error of the first claim, audience or lifetime of the payload that the policy does not allow.
+func (p *IssuancePolicy) Allows(payload config.Payload) error {
+	if p == nil {
+		return IssuanceForbiddenError
+	}
+	for name, value := range payload.CustomPayload {
+		if name == userIDClaim {
+			if !p.allowsUserID(value) {
+				return UserIDForbiddenError
+			}
+			continue
+		}
+		if !allows(p.Claims, name) {
+			return fmt.Errorf("%w: %s", ClaimForbiddenError, name)
+		}
+	}
+	for _, audience := range payload.Audience {
+		if !allows(p.Audiences, audience) {
+			return fmt.Errorf("%w: %s", AudienceForbiddenError, audience)
+		}
+	}
+	if p.MaxTTL > 0 && !p.allowsLifetime(payload) {
+		return TTLForbiddenError
+	}
+	return nil
+}
+
+// allows reports whether the name is one of the allowed names, or they have "*".
+func allows(allowed []string, name string) bool {
+	for _, value := range allowed {
+		if value == "*" || value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsLifetime reports whether the payload expires within MaxTTL of its issuance. Tokens that never expire are not allowed.
+func (p *IssuancePolicy) allowsLifetime(payload config.Payload) bool {
+	if payload.IssuedAt == nil || payload.ExpiredAt == nil {
+		return false
+	}
+	return payload.ExpiredAt.Sub(payload.IssuedAt.Time) <= time.Duration(p.MaxTTL)*time.Second
+}
+
+func (p *IssuancePolicy) allowsUserID(value interface{}) bool {
+	if len(p.UserIDs) == 0 {
+		return true
+	}
+	// user_id is a json.Number from the REST API and a uint64 from the gRPC API
+	userID, err := strconv.ParseUint(fmt.Sprint(value), 10, 64)
+	if err != nil {
+		return false
+	}
+	for _, userIDRange := range p.UserIDs {
+		if userID >= userIDRange.Min && userID <= userIDRange.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// Caller is the authenticated caller of the token generation.
+type Caller struct {
+	ClientID string
+	// Admin callers may issue any claims
+	Admin  bool
+	Policy *IssuancePolicy
+}
+
+// AllowsRefresh reports whether the caller may issue refresh tokens. They are not issued with MaxTTL,
+// as the tokens that they are exchanged for would outlive it.
+func (c Caller) AllowsRefresh() bool {
+	return c.Admin || c.Policy == nil || c.Policy.MaxTTL == 0
+}
+
+// Authorize returns the error of the payload that the caller may not issue.
+func (c Caller) Authorize(payload config.Payload) error {
+	if c.Admin {
+		return nil
+	}
+	return c.Policy.Allows(payload)
+}
+
+// IssuerAuthenticator authenticates the callers of the token generation
+// with the admin token, or the API key or the TLS client certificate of a client.
+type IssuerAuthenticator struct {
+	clients    *Registry
+	adminToken string
+}
+
+// NewIssuerAuthenticator returns the authenticator of the clients. The admin token is disabled when it is empty.
+func NewIssuerAuthenticator(clients *Registry, adminToken string) *IssuerAuthenticator {
+	return &IssuerAuthenticator{
+		clients:    clients,
+		adminToken: adminToken,
+	}
+}
+
+// AuthenticateToken authenticates the admin token or the API key of a client.
+func (a *IssuerAuthenticator) AuthenticateToken(token string) (*Caller, error) {
+	if len(token) == 0 {
+		return nil, InvalidClientError
+	}
+	if len(a.adminToken) > 0 && subtle.ConstantTimeCompare([]byte(token), []byte(a.adminToken)) == 1 {
+		return &Caller{ClientID: AdminClientID, Admin: true}, nil
+	}
+	// API keys are random, so their SHA-256 hashes are looked up instead of compared in constant time
+	hash := sha256.Sum256([]byte(token))
+	clientID, ok := a.clients.apiKeys[hex.EncodeToString(hash[:])]
+	if !ok {
+		return nil, InvalidClientError
+	}
+	return a.caller(clientID), nil
+}
+
+// AuthenticateCertificate authenticates the client of the subject of the verified TLS client certificate.
+func (a *IssuerAuthenticator) AuthenticateCertificate(cert *x509.Certificate) (*Caller, error) {
+	clientID, ok := a.clients.subjects[cert.Subject.String()]
+	if !ok {
+		return nil, InvalidClientError
+	}
+	return a.caller(clientID), nil
+}
+
+func (a *IssuerAuthenticator) caller(clientID string) *Caller {
+	return &Caller{ClientID: clientID, Policy: a.clients.clients[clientID].Issuance}
+}
@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/client"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"time"
+)
+
+var _ = Describe("IssuerAuthenticator", func() {
+	var authenticator *client.IssuerAuthenticator
+
+	BeforeEach(func() {
+		apiKeyHash := sha256.Sum256([]byte("api-key"))
+		registry, err := client.ParseRegistry([]byte(`[
+			{"client_id": "backend", "api_key_hash": "` + hex.EncodeToString(apiKeyHash[:]) + `",
+			 "issuance": {"claims": ["roles"], "user_ids": [{"min": 100, "max": 199}], "audiences": ["billing"]}},
+			{"client_id": "service", "tls_client_auth_subject_dn": "CN=service,O=Heimdall", "issuance": {"claims": ["*"]}},
+			{"client_id": "frontend"}
+		]`))
+		Expect(err).To(BeNil())
+		authenticator = client.NewIssuerAuthenticator(registry, "admin-token")
+	})
+
+	It("authenticates the admin token", func() {
+		caller, err := authenticator.AuthenticateToken("admin-token")
+		Expect(err).To(BeNil())
+		Expect(caller.ClientID).To(Equal(client.AdminClientID))
+		Expect(caller.Authorize(config.Payload{CustomPayload: config.CustomPayload{"user_id": json.Number("1"), "anything": true}})).To(Succeed())
+	})
+
+	It("authenticates the API key of the client", func() {
+		caller, err := authenticator.AuthenticateToken("api-key")
+		Expect(err).To(BeNil())
+		Expect(caller.ClientID).To(Equal("backend"))
+		Expect(caller.Admin).To(BeFalse())
+	})
+
+	It("rejects unknown tokens", func() {
+		_, err := authenticator.AuthenticateToken("wrong")
+		Expect(err).To(Equal(client.InvalidClientError))
+		_, err = authenticator.AuthenticateToken("")
+		Expect(err).To(Equal(client.InvalidClientError))
+	})
+
+	It("rejects the empty admin token when it is disabled", func() {
+		registry, err := client.ParseRegistry(nil)
+		Expect(err).To(BeNil())
+		_, err = client.NewIssuerAuthenticator(registry, "").AuthenticateToken("")
+		Expect(err).To(Equal(client.InvalidClientError))
+	})
+
+	It("authenticates the subject of the certificate", func() {
+		caller, err := authenticator.AuthenticateCertificate(&x509.Certificate{
+			Subject: pkix.Name{CommonName: "service", Organization: []string{"Heimdall"}},
+		})
+		Expect(err).To(BeNil())
+		Expect(caller.ClientID).To(Equal("service"))
+		Expect(caller.Authorize(config.Payload{CustomPayload: config.CustomPayload{"user_id": uint64(1), "roles": []interface{}{"admin"}}})).To(Succeed())
+
+		_, err = authenticator.AuthenticateCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "unknown"}})
+		Expect(err).To(Equal(client.InvalidClientError))
+	})
+
+	It("rejects duplicated credentials", func() {
+		_, err := client.ParseRegistry([]byte(`[{"client_id": "a", "api_key_hash": "ab"}, {"client_id": "b", "api_key_hash": "AB"}]`))
+		Expect(err).To(Equal(client.DuplicateCredentialError))
+	})
+
+	Context("Issuance policy", func() {
+		var caller *client.Caller
+
+		BeforeEach(func() {
+			var err error
+			caller, err = authenticator.AuthenticateToken("api-key")
+			Expect(err).To(BeNil())
+		})
+
+		It("allows the claims and the user IDs of the policy", func() {
+			Expect(caller.Authorize(config.Payload{CustomPayload: config.CustomPayload{"user_id": json.Number("150"), "roles": []interface{}{"admin"}}})).To(Succeed())
+			Expect(caller.Authorize(config.Payload{CustomPayload: config.CustomPayload{"user_id": uint64(199)}})).To(Succeed())
+		})
+
+		It("rejects user IDs out of the ranges", func() {
+			Expect(caller.Authorize(config.Payload{CustomPayload: config.CustomPayload{"user_id": json.Number("200")}})).To(MatchError(client.UserIDForbiddenError))
+			Expect(caller.Authorize(config.Payload{CustomPayload: config.CustomPayload{"user_id": "abc"}})).To(MatchError(client.UserIDForbiddenError))
+		})
+
+		It("rejects other claims", func() {
+			err := caller.Authorize(config.Payload{CustomPayload: config.CustomPayload{"user_id": json.Number("150"), "tenant": "acme"}})
+			Expect(err).To(MatchError(client.ClaimForbiddenError))
+			Expect(err.Error()).To(ContainSubstring("tenant"))
+		})
+
+		It("allows the audiences of the policy", func() {
+			payload := config.Payload{CustomPayload: config.CustomPayload{"user_id": json.Number("150")}}
+			payload.Audience = config.Audience{"billing"}
+			Expect(caller.Authorize(payload)).To(Succeed())
+
+			payload.Audience = config.Audience{"billing", "admin"}
+			err := caller.Authorize(payload)
+			Expect(err).To(MatchError(client.AudienceForbiddenError))
+			Expect(err.Error()).To(ContainSubstring("admin"))
+		})
+
+		It("rejects lifetime longer than max TTL", func() {
+			caller := client.Caller{ClientID: "backend", Policy: &client.IssuancePolicy{MaxTTL: 3600}}
+			now := time.Now()
+			payload := config.Payload{CustomPayload: config.CustomPayload{"user_id": json.Number("150")}}
+			payload.IssuedAt = config.NewNumericDate(now)
+			payload.ExpiredAt = config.NewNumericDate(now.Add(time.Hour))
+			Expect(caller.Authorize(payload)).To(Succeed())
+
+			payload.ExpiredAt = config.NewNumericDate(now.Add(time.Hour + time.Second))
+			Expect(caller.Authorize(payload)).To(MatchError(client.TTLForbiddenError))
+
+			payload.ExpiredAt = nil
+			Expect(caller.Authorize(payload)).To(MatchError(client.TTLForbiddenError))
+		})
+
+		It("allows refresh tokens without max TTL", func() {
+			Expect(caller.AllowsRefresh()).To(BeTrue())
+			Expect(client.Caller{ClientID: client.AdminClientID, Admin: true}.AllowsRefresh()).To(BeTrue())
+			Expect(client.Caller{ClientID: "backend", Policy: &client.IssuancePolicy{MaxTTL: 3600}}.AllowsRefresh()).To(BeFalse())
+		})
+
+		It("rejects clients without policy", func() {
+			Expect(client.Caller{ClientID: "frontend"}.Authorize(config.Payload{CustomPayload: config.CustomPayload{}})).To(MatchError(client.IssuanceForbiddenError))
+		})
+	})
+})
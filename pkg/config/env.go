@@ -9,14 +9,65 @@ import (
 const ProductionMode = "production"
 
 type Config struct {
-	JWSSecretKey         string        `env:"JWS_SECRET_KEY,required"`
-	PayloadEncryptionKey string        `env:"PAYLOAD_ENCRYPTION_KEY"`
-	TokenValidTime       time.Duration `env:"TOKEN_VALID_TIME"`
-	SentryDSN            string        `env:"SENTRY_DSN"`
-	Mode                 string        `env:"MODE" envDefault:"development"`
-	GinMode              string        `env:"GIN_MODE" envDefault:"debug"`
-	GinPort              int           `env:"GIN_PORT" envDefault:"8080"`
-	GRPCPort             int           `env:"GRPC_PORT" envDefault:"5050"`
+	JWSAlgorithm          string        `env:"JWS_ALGORITHM" envDefault:"HS256"`
+	JWSSecretKey          string        `env:"JWS_SECRET_KEY"`
+	JWSPrivateKey         string        `env:"JWS_PRIVATE_KEY_FILE,file"`
+	JWSKeyID              string        `env:"JWS_KEY_ID"`
+	JWSRetiredKeys        string        `env:"JWS_RETIRED_KEYS_FILE,file"`
+	PayloadEncryptionKey  string        `env:"PAYLOAD_ENCRYPTION_KEY"`
+	TokenValidTime        time.Duration `env:"TOKEN_VALID_TIME"`
+	TokenMaxValidTime     time.Duration `env:"TOKEN_MAX_VALID_TIME"`
+	TokenFormat           string        `env:"TOKEN_FORMAT" envDefault:"legacy"`
+	TokenIssuer           string        `env:"TOKEN_ISSUER"`
+	TokenAudience         []string      `env:"TOKEN_AUDIENCE" envSeparator:","`
+	TokenLeeway           time.Duration `env:"TOKEN_LEEWAY"`
+	TokenSources          string        `env:"TOKEN_SOURCES" envDefault:"authorization"`
+	RefreshTokenValidTime time.Duration `env:"REFRESH_TOKEN_VALID_TIME"`
+	BatchMaxSize          int           `env:"BATCH_MAX_SIZE" envDefault:"1000"`
+	BatchWorkers          int           `env:"BATCH_WORKERS" envDefault:"8"`
+	ClaimsSchema          string        `env:"CLAIMS_SCHEMA_FILE,file"`
+	RevocationStore       string        `env:"REVOCATION_STORE" envDefault:"memory"`
+	RevocationBoltPath    string        `env:"REVOCATION_BOLT_PATH" envDefault:"heimdall.db"`
+	RevocationRedisURL    string        `env:"REVOCATION_REDIS_URL"`
+	Clients               string        `env:"CLIENTS_FILE,file"`
+	AdminToken            string        `env:"ADMIN_TOKEN"`
+	AuthorizationRules    string        `env:"AUTHORIZATION_RULES_FILE,file"`
+	ForwardAuthLoginURL   string        `env:"FORWARD_AUTH_LOGIN_URL"`
+	ForwardAuthHeaders    []string      `env:"FORWARD_AUTH_RESPONSE_HEADERS" envSeparator:","`
+	TrustedProxies        []string      `env:"TRUSTED_PROXIES" envSeparator:","`
+	ForwardedHeaders      string        `env:"FORWARDED_HEADERS" envDefault:"traefik"`
+	ClaimHeaders          string        `env:"CLAIM_HEADERS_FILE,file"`
+	TLSCertificate        string        `env:"TLS_CERT_FILE,file"`
+	TLSPrivateKey         string        `env:"TLS_KEY_FILE,file"`
+	TLSClientCA           string        `env:"TLS_CLIENT_CA_FILE,file"`
+	JWKSCacheMaxAge       time.Duration `env:"JWKS_CACHE_MAX_AGE" envDefault:"15m"`
+	SentryDSN             string        `env:"SENTRY_DSN"`
+	Mode                  string        `env:"MODE" envDefault:"development"`
+	GinMode               string        `env:"GIN_MODE" envDefault:"debug"`
+	GinPort               int           `env:"GIN_PORT" envDefault:"8080"`
+	GRPCPort              int           `env:"GRPC_PORT" envDefault:"5050"`
+}
+
+// MaxTokenValidTime is the longest that the tokens with their own TTL, of the gRPC requests or of the OAuth clients,
+// may be valid for. It defaults to TokenValidTime.
+func (c Config) MaxTokenValidTime() time.Duration {
+	if c.TokenMaxValidTime > 0 {
+		return c.TokenMaxValidTime
+	}
+	return c.TokenValidTime
+}
+
+// RevocationRetention is how long a revoked jti is kept, which covers the remaining lifetime of every token
+// issued so far, including the refresh tokens. It is zero, meaning forever, when the tokens never expire.
+func (c Config) RevocationRetention() time.Duration {
+	if c.TokenValidTime <= 0 {
+		return 0
+	}
+	validTime := c.MaxTokenValidTime()
+	if c.RefreshTokenValidTime > validTime {
+		validTime = c.RefreshTokenValidTime
+	}
+	return validTime + c.TokenLeeway
 }
 
 func ParseConfig() (*Config, error) {
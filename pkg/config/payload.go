@@ -1,17 +1,153 @@
 package config
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
 
+var ClaimsObjectError = errors.New("claims must be a JSON object")
+
+// RegisteredClaimNames is the names of the RFC 7519 registered claims in MetadataPayload.
+var RegisteredClaimNames = []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti"}
+
+// NumericDate is the RFC 7519 NumericDate, the number of seconds since the epoch.
+type NumericDate struct {
+	time.Time
+}
+
+func NewNumericDate(t time.Time) *NumericDate {
+	return &NumericDate{t.Truncate(time.Second).UTC()}
+}
+
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(d.Unix(), 10)), nil
+}
+
+func (d *NumericDate) UnmarshalJSON(b []byte) error {
+	var seconds json.Number
+	if err := json.Unmarshal(b, &seconds); err != nil {
+		return err
+	}
+	f, err := seconds.Float64()
+	if err != nil {
+		return err
+	}
+	d.Time = time.Unix(int64(f), 0).UTC()
+	return nil
+}
+
+// Audience is the "aud" claim, which is either a single string or an array of strings.
+type Audience []string
+
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// Contains reports whether the audience includes aud.
+func (a Audience) Contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// MetadataPayload is the registered claims of RFC 7519.
 type MetadataPayload struct {
-	IssuedAt  time.Time `json:"issued_at"`
-	ExpiredAt time.Time `json:"expired_at"`
+	Issuer    string       `json:"iss,omitempty"`
+	Subject   string       `json:"sub,omitempty"`
+	Audience  Audience     `json:"aud,omitempty" swaggertype:"array,string"`
+	ExpiredAt *NumericDate `json:"exp,omitempty" swaggertype:"integer"`
+	NotBefore *NumericDate `json:"nbf,omitempty" swaggertype:"integer"`
+	IssuedAt  *NumericDate `json:"iat,omitempty" swaggertype:"integer"`
+	ID        string       `json:"jti,omitempty"`
 }
 
-type CustomPayload struct {
-	UserID uint64 `json:"user_id" binding:"required" header:"X-USER-ID"`
+// CustomPayload is the private claims of the token. Numbers are kept as json.Number.
+type CustomPayload map[string]interface{}
+
+// DecodeCustomPayload decodes a JSON object of claims.
+func DecodeCustomPayload(r io.Reader) (CustomPayload, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var claims CustomPayload
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, err
+	}
+	if claims == nil {
+		return nil, ClaimsObjectError
+	}
+	return claims, nil
 }
 
+// Payload is the claims of the token, flattened into a single JSON object.
 type Payload struct {
 	CustomPayload
 	MetadataPayload
 }
+
+func (p Payload) MarshalJSON() ([]byte, error) {
+	claims := make(map[string]json.RawMessage, len(p.CustomPayload))
+	for name, value := range p.CustomPayload {
+		rawValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		claims[name] = rawValue
+	}
+	metadata, err := json.Marshal(p.MetadataPayload)
+	if err != nil {
+		return nil, err
+	}
+	// The registered claims take precedence over the custom claims of the same name
+	if err := json.Unmarshal(metadata, &claims); err != nil {
+		return nil, err
+	}
+	return json.Marshal(claims)
+}
+
+// Claims returns every claim of the payload as it is in the token, including the registered claims.
+func (p Payload) Claims() (CustomPayload, error) {
+	rawPayload, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeCustomPayload(bytes.NewReader(rawPayload))
+}
+
+func (p *Payload) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &p.MetadataPayload); err != nil {
+		return err
+	}
+	claims, err := DecodeCustomPayload(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for _, name := range RegisteredClaimNames {
+		delete(claims, name)
+	}
+	p.CustomPayload = claims
+	return nil
+}
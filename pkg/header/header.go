@@ -0,0 +1,62 @@
+package header
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"regexp"
+	"strings"
+)
+
+var claimNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ClaimHeaderName returns the header that the claim is set to, e.g. X-USER-ID for user_id.
+func ClaimHeaderName(claim string) string {
+	return "X-" + strings.ToUpper(strings.ReplaceAll(claim, "_", "-"))
+}
+
+// ClaimHeaders returns the headers of the custom claims, skipping the claims whose names cannot be a header name.
+func ClaimHeaders(claims config.CustomPayload) (map[string]string, error) {
+	headers := make(map[string]string, len(claims))
+	for name, value := range claims {
+		if !claimNameRegex.MatchString(name) {
+			continue
+		}
+		headerValue, err := claimHeaderValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("claim %s: %w", name, err)
+		}
+		headers[ClaimHeaderName(name)] = headerValue
+	}
+	return headers, nil
+}
+
+// claimHeaderValue formats strings and numbers as is, arrays of them as comma separated values and the rest as JSON.
+func claimHeaderValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number, bool, float64, int, int64, uint64:
+		return fmt.Sprint(v), nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, element := range v {
+			switch element.(type) {
+			case map[string]interface{}, []interface{}:
+				return marshalClaimHeaderValue(value)
+			}
+			elementValue, err := claimHeaderValue(element)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, elementValue)
+		}
+		return strings.Join(values, ","), nil
+	}
+	return marshalClaimHeaderValue(value)
+}
+
+func marshalClaimHeaderValue(value interface{}) (string, error) {
+	rawValue, err := json.Marshal(value)
+	return string(rawValue), err
+}
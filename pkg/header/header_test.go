@@ -0,0 +1,32 @@
+package header_test
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/header"
+)
+
+var _ = Describe("Claim headers", func() {
+	It("names the header after the claim", func() {
+		Expect(header.ClaimHeaderName("user_id")).To(Equal("X-USER-ID"))
+	})
+
+	It("formats the claims", func() {
+		headers, err := header.ClaimHeaders(config.CustomPayload{
+			"user_id":   json.Number("99"),
+			"email":     "user@example.com",
+			"roles":     []interface{}{"admin", "editor"},
+			"tenant":    map[string]interface{}{"id": "acme"},
+			"bad claim": "skipped",
+		})
+		Expect(err).To(BeNil())
+		Expect(headers).To(Equal(map[string]string{
+			"X-USER-ID": "99",
+			"X-EMAIL":   "user@example.com",
+			"X-ROLES":   "admin,editor",
+			"X-TENANT":  `{"id":"acme"}`,
+		}))
+	})
+})
@@ -0,0 +1,218 @@
+package header
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The encodings of the claim of a Mapping.
+const (
+	// DefaultEncoding formats strings and numbers as is, arrays of them as comma separated values and the rest as JSON
+	DefaultEncoding = ""
+	// JoinEncoding formats the elements of arrays with DefaultEncoding, joined by the separator of the mapping
+	JoinEncoding = "join"
+	// JSONEncoding formats the claim as JSON
+	JSONEncoding = "json"
+	// Base64Encoding formats the claim with DefaultEncoding, then as standard base64, e.g. for non-ASCII values
+	Base64Encoding = "base64"
+)
+
+const defaultSeparator = ","
+
+var InvalidMappingError = errors.New("invalid claim header mapping")
+
+var headerNameRegex = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// Mapping sets the claim to the header. The claim is a dot separated path into the claims, e.g. profile.email
+// or groups.0 for the first element of an array, and may be a registered claim such as exp.
+type Mapping struct {
+	Claim     string `json:"claim"`
+	Header    string `json:"header"`
+	Encoding  string `json:"encoding,omitempty"`
+	Separator string `json:"separator,omitempty"`
+}
+
+// MapperConfig is the mappings of the claims, and the header of every claim as base64 encoded JSON, e.g. X-Userinfo.
+type MapperConfig struct {
+	Headers      []Mapping `json:"headers"`
+	ClaimsHeader string    `json:"claims_header,omitempty"`
+}
+
+// Mapper sets the claims of the payloads to the headers. A nil Mapper, or the Mapper of NewDefaultMapper,
+// sets every custom claim to its ClaimHeaderName.
+type Mapper struct {
+	mappings     []Mapping
+	claimsHeader string
+	// claims are the claims of the default mapper, whose headers it may set
+	claims []string
+}
+
+// NewDefaultMapper returns the mapper that sets every custom claim to its ClaimHeaderName, and whose Names are the headers
+// of the claims, e.g. the properties of the claims schema, so they are stripped from the requests as well.
+func NewDefaultMapper(claims []string) *Mapper {
+	return &Mapper{claims: claims}
+}
+
+// NewMapper returns the mapper of the config, or nil if the config has neither mappings nor a claims header.
+func NewMapper(cfg MapperConfig) (*Mapper, error) {
+	if len(cfg.Headers) == 0 && len(cfg.ClaimsHeader) == 0 {
+		return nil, nil
+	}
+	names := make(map[string]bool, len(cfg.Headers)+1)
+	if len(cfg.ClaimsHeader) > 0 {
+		if !headerNameRegex.MatchString(cfg.ClaimsHeader) {
+			return nil, fmt.Errorf("%w: claims header %q", InvalidMappingError, cfg.ClaimsHeader)
+		}
+		names[http.CanonicalHeaderKey(cfg.ClaimsHeader)] = true
+	}
+	mappings := make([]Mapping, len(cfg.Headers))
+	for i, mapping := range cfg.Headers {
+		if len(mapping.Claim) == 0 || !headerNameRegex.MatchString(mapping.Header) {
+			return nil, fmt.Errorf("%w: claim %q to header %q", InvalidMappingError, mapping.Claim, mapping.Header)
+		}
+		switch mapping.Encoding {
+		case DefaultEncoding, JoinEncoding, JSONEncoding, Base64Encoding:
+		default:
+			return nil, fmt.Errorf("%w: encoding %q of claim %s", InvalidMappingError, mapping.Encoding, mapping.Claim)
+		}
+		name := http.CanonicalHeaderKey(mapping.Header)
+		if names[name] {
+			return nil, fmt.Errorf("%w: duplicate header %s", InvalidMappingError, mapping.Header)
+		}
+		names[name] = true
+		if len(mapping.Separator) == 0 {
+			mapping.Separator = defaultSeparator
+		}
+		mappings[i] = mapping
+	}
+	return &Mapper{mappings: mappings, claimsHeader: cfg.ClaimsHeader}, nil
+}
+
+// ParseMapper returns the mapper of the JSON config, or nil if the data is empty.
+func ParseMapper(data []byte) (*Mapper, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var cfg MapperConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidMappingError, err)
+	}
+	return NewMapper(cfg)
+}
+
+// Headers returns the headers of the payload. The headers of the claims that the payload does not have are omitted.
+func (m *Mapper) Headers(payload *config.Payload) (map[string]string, error) {
+	if m.isDefault() {
+		return ClaimHeaders(payload.CustomPayload)
+	}
+	claims, err := payload.Claims()
+	if err != nil {
+		return nil, err
+	}
+	headers := make(map[string]string, len(m.mappings)+1)
+	for _, mapping := range m.mappings {
+		value, ok := lookupClaim(claims, mapping.Claim)
+		if !ok {
+			continue
+		}
+		headerValue, err := encodeClaim(value, mapping)
+		if err != nil {
+			return nil, fmt.Errorf("claim %s: %w", mapping.Claim, err)
+		}
+		headers[mapping.Header] = headerValue
+	}
+	if len(m.claimsHeader) > 0 {
+		rawClaims, err := json.Marshal(claims)
+		if err != nil {
+			return nil, err
+		}
+		headers[m.claimsHeader] = base64.StdEncoding.EncodeToString(rawClaims)
+	}
+	return headers, nil
+}
+
+// Names returns the canonical names of every header that the mapper may set, which are stripped from the requests
+// when the payload does not have their claims. It is empty for a nil Mapper, as its headers depend on the claims.
+func (m *Mapper) Names() []string {
+	if m == nil {
+		return nil
+	}
+	names := make([]string, 0, len(m.mappings)+len(m.claims)+1)
+	for _, claim := range m.claims {
+		if claimNameRegex.MatchString(claim) {
+			names = append(names, http.CanonicalHeaderKey(ClaimHeaderName(claim)))
+		}
+	}
+	for _, mapping := range m.mappings {
+		names = append(names, http.CanonicalHeaderKey(mapping.Header))
+	}
+	if len(m.claimsHeader) > 0 {
+		names = append(names, http.CanonicalHeaderKey(m.claimsHeader))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isDefault reports whether the mapper sets every custom claim to its ClaimHeaderName.
+func (m *Mapper) isDefault() bool {
+	return m == nil || (len(m.mappings) == 0 && len(m.claimsHeader) == 0)
+}
+
+// lookupClaim returns the value at the dot separated path of the claims.
+func lookupClaim(claims config.CustomPayload, path string) (interface{}, bool) {
+	var value interface{} = map[string]interface{}(claims)
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[key]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, value != nil
+}
+
+func encodeClaim(value interface{}, mapping Mapping) (string, error) {
+	switch mapping.Encoding {
+	case JoinEncoding:
+		values, ok := value.([]interface{})
+		if !ok {
+			return claimHeaderValue(value)
+		}
+		elements := make([]string, 0, len(values))
+		for _, element := range values {
+			elementValue, err := claimHeaderValue(element)
+			if err != nil {
+				return "", err
+			}
+			elements = append(elements, elementValue)
+		}
+		return strings.Join(elements, mapping.Separator), nil
+	case JSONEncoding:
+		return marshalClaimHeaderValue(value)
+	case Base64Encoding:
+		headerValue, err := claimHeaderValue(value)
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString([]byte(headerValue)), nil
+	}
+	return claimHeaderValue(value)
+}
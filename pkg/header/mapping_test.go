@@ -0,0 +1,104 @@
+package header_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/header"
+	"time"
+)
+
+var _ = Describe("Mapper", func() {
+	payload := &config.Payload{
+		CustomPayload: config.CustomPayload{
+			"user_id": json.Number("99"),
+			"roles":   []interface{}{"admin", "editor"},
+			"profile": map[string]interface{}{"email": "user@example.com", "name": "Zoë"},
+			"groups":  []interface{}{map[string]interface{}{"id": "g1"}},
+		},
+		MetadataPayload: config.MetadataPayload{
+			Subject:   "99",
+			ExpiredAt: config.NewNumericDate(time.Unix(1700000000, 0)),
+		},
+	}
+
+	It("sets the mapped claims to the headers", func() {
+		mapper, err := header.ParseMapper([]byte(`{
+			"headers": [
+				{"claim": "user_id", "header": "X-User-Id"},
+				{"claim": "profile.email", "header": "X-Email"},
+				{"claim": "roles", "header": "X-Roles", "encoding": "join", "separator": " "},
+				{"claim": "groups", "header": "X-Groups", "encoding": "json"},
+				{"claim": "groups.0.id", "header": "X-Group"},
+				{"claim": "profile.name", "header": "X-Name", "encoding": "base64"},
+				{"claim": "exp", "header": "X-Token-Expiry"},
+				{"claim": "tenant", "header": "X-Tenant"}
+			]
+		}`))
+		Expect(err).To(BeNil())
+		headers, err := mapper.Headers(payload)
+		Expect(err).To(BeNil())
+		Expect(headers).To(Equal(map[string]string{
+			"X-User-Id":      "99",
+			"X-Email":        "user@example.com",
+			"X-Roles":        "admin editor",
+			"X-Groups":       `[{"id":"g1"}]`,
+			"X-Group":        "g1",
+			"X-Name":         base64.StdEncoding.EncodeToString([]byte("Zoë")),
+			"X-Token-Expiry": "1700000000",
+		}))
+		Expect(mapper.Names()).To(ConsistOf("X-User-Id", "X-Email", "X-Roles", "X-Groups", "X-Group", "X-Name", "X-Token-Expiry", "X-Tenant"))
+	})
+
+	It("sets every claim to the claims header", func() {
+		mapper, err := header.NewMapper(header.MapperConfig{ClaimsHeader: "X-Userinfo"})
+		Expect(err).To(BeNil())
+		headers, err := mapper.Headers(payload)
+		Expect(err).To(BeNil())
+		rawClaims, err := base64.StdEncoding.DecodeString(headers["X-Userinfo"])
+		Expect(err).To(BeNil())
+		Expect(rawClaims).To(MatchJSON(`{
+			"user_id": 99,
+			"roles": ["admin", "editor"],
+			"profile": {"email": "user@example.com", "name": "Zoë"},
+			"groups": [{"id": "g1"}],
+			"sub": "99",
+			"exp": 1700000000
+		}`))
+	})
+
+	It("sets every custom claim to its header without a config", func() {
+		mapper, err := header.ParseMapper(nil)
+		Expect(err).To(BeNil())
+		headers, err := mapper.Headers(payload)
+		Expect(err).To(BeNil())
+		Expect(headers).To(HaveKeyWithValue("X-USER-ID", "99"))
+		Expect(headers).To(HaveKeyWithValue("X-ROLES", "admin,editor"))
+		Expect(mapper.Names()).To(BeEmpty())
+	})
+
+	It("names the headers of the claims of the default mapper", func() {
+		mapper := header.NewDefaultMapper([]string{"user_id", "roles", "bad claim"})
+		headers, err := mapper.Headers(payload)
+		Expect(err).To(BeNil())
+		Expect(headers).To(HaveKeyWithValue("X-USER-ID", "99"))
+		Expect(headers).To(HaveKeyWithValue("X-ROLES", "admin,editor"))
+		Expect(mapper.Names()).To(Equal([]string{"X-Roles", "X-User-Id"}))
+	})
+
+	It("returns InvalidMappingError for invalid configs", func() {
+		for _, data := range []string{
+			`{"headers": [{"claim": "user_id", "header": "X User"}]}`,
+			`{"headers": [{"claim": "", "header": "X-User"}]}`,
+			`{"headers": [{"claim": "user_id", "header": "X-User", "encoding": "hex"}]}`,
+			`{"headers": [{"claim": "user_id", "header": "X-User"}, {"claim": "sub", "header": "x-user"}]}`,
+			`{"claims_header": "X:Userinfo"}`,
+			`[]`,
+		} {
+			_, err := header.ParseMapper([]byte(data))
+			Expect(err).To(MatchError(header.InvalidMappingError), data)
+		}
+	})
+})
@@ -0,0 +1,163 @@
+package revocation
+
+import (
+	"context"
+	"encoding/binary"
+	bolt "go.etcd.io/bbolt"
+	"sync"
+	"time"
+)
+
+var (
+	tokenBucket   = []byte("revoked_tokens")
+	subjectBucket = []byte("revoked_subjects")
+	usedBucket    = []byte("used_tokens")
+)
+
+// boltCleanupInterval is how often the expired revoked and used tokens are deleted.
+// Deleting them scans the buckets, so it is kept off the write transactions of the requests.
+const boltCleanupInterval = time.Minute
+
+// NewBoltStore opens the BoltDB file at the path as a Store, which survives restarts of a single instance.
+func NewBoltStore(path string) (*boltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{tokenBucket, subjectBucket, usedBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	store := &boltStore{db: db, now: time.Now, done: make(chan struct{})}
+	if err := store.deleteExpired(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	store.wg.Add(1)
+	go store.cleanup(boltCleanupInterval)
+	return store, nil
+}
+
+type boltStore struct {
+	db   *bolt.DB
+	now  func() time.Time
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func (s *boltStore) RevokeToken(_ context.Context, id string, expiredAt time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(tokenBucket).Put([]byte(id), encodeTime(expiredAt))
+	})
+}
+
+func (s *boltStore) RevokeSubject(_ context.Context, subject string, issuedBefore time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(subjectBucket)
+		if !issuedBefore.After(decodeTime(bucket.Get([]byte(subject)))) {
+			return nil
+		}
+		return bucket.Put([]byte(subject), encodeTime(issuedBefore))
+	})
+}
+
+func (s *boltStore) IsRevoked(_ context.Context, id, subject string, issuedAt time.Time) (bool, error) {
+	revoked := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if len(id) > 0 && tx.Bucket(tokenBucket).Get([]byte(id)) != nil {
+			revoked = true
+			return nil
+		}
+		if len(subject) > 0 {
+			revoked = isRevokedSubject(issuedAt, decodeTime(tx.Bucket(subjectBucket).Get([]byte(subject))))
+		}
+		return nil
+	})
+	return revoked, err
+}
+
+func (s *boltStore) UseToken(_ context.Context, id string, expiredAt time.Time) (bool, error) {
+	used := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(usedBucket)
+		if bucket.Get([]byte(id)) != nil {
+			used = true
+			return nil
+		}
+		return bucket.Put([]byte(id), encodeTime(expiredAt))
+	})
+	return used, err
+}
+
+func (s *boltStore) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return s.db.Close()
+}
+
+// cleanup deletes the expired tokens every interval until the store is closed.
+func (s *boltStore) cleanup(interval time.Duration) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			// The expired tokens are deleted on the next tick if this one fails
+			_ = s.deleteExpired()
+		}
+	}
+}
+
+func (s *boltStore) deleteExpired() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		now := s.now()
+		for _, bucket := range [][]byte{tokenBucket, usedBucket} {
+			if err := deleteExpiredKeys(tx.Bucket(bucket), now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func deleteExpiredKeys(bucket *bolt.Bucket, now time.Time) error {
+	cursor := bucket.Cursor()
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		if expiredAt := decodeTime(v); !expiredAt.IsZero() && expiredAt.Before(now) {
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func encodeTime(t time.Time) []byte {
+	b := make([]byte, 8)
+	if !t.IsZero() {
+		binary.BigEndian.PutUint64(b, uint64(t.UnixNano()))
+	}
+	return b
+}
+
+func decodeTime(b []byte) time.Time {
+	if len(b) != 8 {
+		return time.Time{}
+	}
+	nanos := binary.BigEndian.Uint64(b)
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(nanos))
+}
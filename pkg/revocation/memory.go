@@ -0,0 +1,74 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NewMemoryStore returns a Store that is lost on restart and not shared between instances.
+func NewMemoryStore() *memoryStore {
+	return &memoryStore{
+		tokens:   map[string]time.Time{},
+		subjects: map[string]time.Time{},
+		used:     map[string]time.Time{},
+		now:      time.Now,
+	}
+}
+
+type memoryStore struct {
+	mu       sync.RWMutex
+	tokens   map[string]time.Time
+	subjects map[string]time.Time
+	used     map[string]time.Time
+	now      func() time.Time
+}
+
+func (s *memoryStore) RevokeToken(_ context.Context, id string, expiredAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleteExpired(s.tokens, s.now())
+	s.tokens[id] = expiredAt
+	return nil
+}
+
+func (s *memoryStore) RevokeSubject(_ context.Context, subject string, issuedBefore time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if issuedBefore.After(s.subjects[subject]) {
+		s.subjects[subject] = issuedBefore
+	}
+	return nil
+}
+
+func (s *memoryStore) IsRevoked(_ context.Context, id, subject string, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.tokens[id]; ok && len(id) > 0 {
+		return true, nil
+	}
+	return len(subject) > 0 && isRevokedSubject(issuedAt, s.subjects[subject]), nil
+}
+
+func (s *memoryStore) UseToken(_ context.Context, id string, expiredAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleteExpired(s.used, s.now())
+	if _, ok := s.used[id]; ok {
+		return true, nil
+	}
+	s.used[id] = expiredAt
+	return false, nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+func deleteExpired(tokens map[string]time.Time, now time.Time) {
+	for id, expiredAt := range tokens {
+		if !expiredAt.IsZero() && expiredAt.Before(now) {
+			delete(tokens, id)
+		}
+	}
+}
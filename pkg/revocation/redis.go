@@ -0,0 +1,97 @@
+package revocation
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"time"
+)
+
+// revokeSubjectScript only moves the issued-before time of the subject forward.
+var revokeSubjectScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current or tonumber(current) < tonumber(ARGV[1]) then
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// NewRedisStore returns a Store on Redis or any Redis-compatible server, shared between instances.
+// Every key is prefixed with the prefix.
+func NewRedisStore(client redis.UniversalClient, prefix string) *redisStore {
+	return &redisStore{client: client, prefix: prefix, now: time.Now}
+}
+
+type redisStore struct {
+	client redis.UniversalClient
+	prefix string
+	now    func() time.Time
+}
+
+func (s *redisStore) tokenKey(id string) string {
+	return s.prefix + "revoked:token:" + id
+}
+
+func (s *redisStore) subjectKey(subject string) string {
+	return s.prefix + "revoked:subject:" + subject
+}
+
+func (s *redisStore) usedKey(id string) string {
+	return s.prefix + "used:token:" + id
+}
+
+func (s *redisStore) RevokeToken(ctx context.Context, id string, expiredAt time.Time) error {
+	ttl, ok := s.ttl(expiredAt)
+	if !ok {
+		return nil
+	}
+	return s.client.Set(ctx, s.tokenKey(id), 1, ttl).Err()
+}
+
+func (s *redisStore) RevokeSubject(ctx context.Context, subject string, issuedBefore time.Time) error {
+	return revokeSubjectScript.Run(ctx, s.client, []string{s.subjectKey(subject)}, issuedBefore.UnixNano()).Err()
+}
+
+func (s *redisStore) IsRevoked(ctx context.Context, id, subject string, issuedAt time.Time) (bool, error) {
+	values, err := s.client.MGet(ctx, s.tokenKey(id), s.subjectKey(subject)).Result()
+	if err != nil {
+		return false, err
+	}
+	if values[0] != nil && len(id) > 0 {
+		return true, nil
+	}
+	if values[1] == nil || len(subject) == 0 {
+		return false, nil
+	}
+	nanos, err := strconv.ParseInt(values[1].(string), 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return isRevokedSubject(issuedAt, time.Unix(0, nanos)), nil
+}
+
+func (s *redisStore) UseToken(ctx context.Context, id string, expiredAt time.Time) (bool, error) {
+	ttl, ok := s.ttl(expiredAt)
+	if !ok {
+		// The token is already expired, so it cannot be used anyway
+		return true, nil
+	}
+	set, err := s.client.SetNX(ctx, s.usedKey(id), 1, ttl).Result()
+	if err != nil {
+		return false, err
+	}
+	return !set, nil
+}
+
+// ttl returns the TTL of the key kept until expiredAt, which is zero for no expiry, and false when it is already expired.
+func (s *redisStore) ttl(expiredAt time.Time) (time.Duration, bool) {
+	if expiredAt.IsZero() {
+		return 0, true
+	}
+	ttl := expiredAt.Sub(s.now())
+	return ttl, ttl > 0
+}
+
+func (s *redisStore) Close() error {
+	return s.client.Close()
+}
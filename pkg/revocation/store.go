@@ -0,0 +1,56 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+const (
+	MemoryStore = "memory"
+	BoltStore   = "bolt"
+	RedisStore  = "redis"
+)
+
+var UnknownStoreError = errors.New("unknown revocation store")
+
+// Store keeps the revoked tokens.
+type Store interface {
+	// RevokeToken revokes the token of the jti. It is kept until expiredAt, or forever when expiredAt is zero.
+	RevokeToken(ctx context.Context, id string, expiredAt time.Time) error
+	// RevokeSubject revokes every token of the subject issued before issuedBefore.
+	RevokeSubject(ctx context.Context, subject string, issuedBefore time.Time) error
+	// IsRevoked reports whether the token is revoked by its jti or by its subject.
+	// Tokens without issuedAt are revoked by any revocation of their subject.
+	IsRevoked(ctx context.Context, id, subject string, issuedAt time.Time) (bool, error)
+	// UseToken marks the single-use token of the jti as used until expiredAt, and reports whether it was used before.
+	// It is kept apart from the revoked tokens, so a used token is not revoked.
+	UseToken(ctx context.Context, id string, expiredAt time.Time) (bool, error)
+	Close() error
+}
+
+// NewStore opens the store of the kind, which is one of MemoryStore, BoltStore and RedisStore.
+func NewStore(kind, boltPath, redisURL string) (Store, error) {
+	switch kind {
+	case MemoryStore:
+		return NewMemoryStore(), nil
+	case BoltStore:
+		store, err := NewBoltStore(boltPath)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case RedisStore:
+		opts, err := redis.ParseURL(redisURL)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedisStore(redis.NewClient(opts), ""), nil
+	}
+	return nil, UnknownStoreError
+}
+
+func isRevokedSubject(issuedAt, issuedBefore time.Time) bool {
+	return !issuedBefore.IsZero() && (issuedAt.IsZero() || issuedAt.Before(issuedBefore))
+}
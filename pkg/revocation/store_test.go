@@ -0,0 +1,182 @@
+package revocation_test
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"path/filepath"
+	"time"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		ctx = context.Background()
+		now time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+	})
+
+	behavesLikeStore := func(newStore func() revocation.Store) {
+		var store revocation.Store
+
+		BeforeEach(func() {
+			store = newStore()
+		})
+
+		AfterEach(func() {
+			Expect(store.Close()).To(Succeed())
+		})
+
+		It("does not revoke unknown token", func() {
+			revoked, err := store.IsRevoked(ctx, "token-id", "99", now)
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("revokes the token of the jti", func() {
+			Expect(store.RevokeToken(ctx, "token-id", now.Add(time.Hour))).To(Succeed())
+
+			revoked, err := store.IsRevoked(ctx, "token-id", "99", now)
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeTrue())
+
+			revoked, err = store.IsRevoked(ctx, "other-token-id", "99", now)
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("revokes the token of the jti forever", func() {
+			Expect(store.RevokeToken(ctx, "token-id", time.Time{})).To(Succeed())
+
+			revoked, err := store.IsRevoked(ctx, "token-id", "99", now)
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("revokes the tokens of the subject issued before the time", func() {
+			Expect(store.RevokeSubject(ctx, "99", now)).To(Succeed())
+
+			revoked, err := store.IsRevoked(ctx, "token-id", "99", now.Add(-time.Second))
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeTrue())
+
+			revoked, err = store.IsRevoked(ctx, "token-id", "99", now.Add(time.Second))
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeFalse())
+
+			revoked, err = store.IsRevoked(ctx, "token-id", "100", now.Add(-time.Second))
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("revokes the tokens of the subject without issued time", func() {
+			Expect(store.RevokeSubject(ctx, "99", now)).To(Succeed())
+
+			revoked, err := store.IsRevoked(ctx, "", "99", time.Time{})
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("never moves the issued time of the subject backward", func() {
+			Expect(store.RevokeSubject(ctx, "99", now)).To(Succeed())
+			Expect(store.RevokeSubject(ctx, "99", now.Add(-time.Hour))).To(Succeed())
+
+			revoked, err := store.IsRevoked(ctx, "token-id", "99", now.Add(-time.Minute))
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("uses the token only once", func() {
+			used, err := store.UseToken(ctx, "token-id", now.Add(time.Hour))
+			Expect(err).To(BeNil())
+			Expect(used).To(BeFalse())
+
+			used, err = store.UseToken(ctx, "token-id", now.Add(time.Hour))
+			Expect(err).To(BeNil())
+			Expect(used).To(BeTrue())
+
+			revoked, err := store.IsRevoked(ctx, "token-id", "99", now)
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeFalse())
+		})
+	}
+
+	Context("Memory", func() {
+		behavesLikeStore(func() revocation.Store {
+			return revocation.NewMemoryStore()
+		})
+	})
+
+	Context("BoltDB", func() {
+		behavesLikeStore(func() revocation.Store {
+			store, err := revocation.NewBoltStore(filepath.Join(GinkgoT().TempDir(), "heimdall.db"))
+			Expect(err).To(BeNil())
+			return store
+		})
+
+		It("keeps the revoked tokens after reopening", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "heimdall.db")
+			store, err := revocation.NewBoltStore(path)
+			Expect(err).To(BeNil())
+			Expect(store.RevokeToken(ctx, "token-id", now.Add(time.Hour))).To(Succeed())
+			Expect(store.Close()).To(Succeed())
+
+			store, err = revocation.NewBoltStore(path)
+			Expect(err).To(BeNil())
+			defer store.Close()
+			revoked, err := store.IsRevoked(ctx, "token-id", "", now)
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("deletes the expired tokens after reopening", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "heimdall.db")
+			store, err := revocation.NewBoltStore(path)
+			Expect(err).To(BeNil())
+			Expect(store.RevokeToken(ctx, "token-id", now.Add(-time.Minute))).To(Succeed())
+			used, err := store.UseToken(ctx, "refresh-token-id", now.Add(-time.Minute))
+			Expect(err).To(BeNil())
+			Expect(used).To(BeFalse())
+			Expect(store.Close()).To(Succeed())
+
+			store, err = revocation.NewBoltStore(path)
+			Expect(err).To(BeNil())
+			defer store.Close()
+			revoked, err := store.IsRevoked(ctx, "token-id", "", now)
+			Expect(err).To(BeNil())
+			Expect(revoked).To(BeFalse())
+			used, err = store.UseToken(ctx, "refresh-token-id", now.Add(time.Hour))
+			Expect(err).To(BeNil())
+			Expect(used).To(BeFalse())
+		})
+	})
+
+	Context("Redis", func() {
+		var server *miniredis.Miniredis
+
+		BeforeEach(func() {
+			server = miniredis.RunT(GinkgoT())
+		})
+
+		behavesLikeStore(func() revocation.Store {
+			return revocation.NewRedisStore(redis.NewClient(&redis.Options{Addr: server.Addr()}), "heimdall:")
+		})
+
+		It("expires the revoked token", func() {
+			store := revocation.NewRedisStore(redis.NewClient(&redis.Options{Addr: server.Addr()}), "heimdall:")
+			defer store.Close()
+			Expect(store.RevokeToken(ctx, "token-id", now.Add(time.Hour))).To(Succeed())
+			Expect(server.TTL("heimdall:revoked:token:token-id")).To(BeNumerically("~", time.Hour, time.Minute))
+		})
+	})
+
+	It("rejects unknown store", func() {
+		_, err := revocation.NewStore("etcd", "", "")
+		Expect(err).To(Equal(revocation.UnknownStoreError))
+	})
+})
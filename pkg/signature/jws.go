@@ -10,6 +10,16 @@ type Manager interface {
 	Verify(token []byte) ([]byte, error)
 }
 
+type Signer interface {
+	Sign(payload []byte) ([]byte, error)
+}
+
+// SignerProvider is implemented by the managers whose signing key can change,
+// so that many payloads can be signed with the key looked up once.
+type SignerProvider interface {
+	Signer() Signer
+}
+
 type jws struct {
 	encryptionKey []byte
 }
@@ -0,0 +1,82 @@
+package signature
+
+import (
+	"bytes"
+	"errors"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+var (
+	MissingKeyError           = errors.New("signature key is missing")
+	UnsupportedAlgorithmError = errors.New("unsupported signature algorithm")
+	KeyTypeMismatchError      = errors.New("key type does not match signature algorithm")
+	PrivateKeyRequiredError   = errors.New("private key is required to sign")
+)
+
+// ParseKey parses a PEM (PKCS#1, PKCS#8, SEC 1 or PKIX) or JWK encoded key.
+func ParseKey(data []byte) (jwk.Key, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, MissingKeyError
+	}
+	if data[0] == '{' {
+		return jwk.ParseKey(data)
+	}
+	return jwk.ParseKey(data, jwk.WithPEM(true))
+}
+
+func checkKeyType(alg jwa.SignatureAlgorithm, key jwk.Key) error {
+	var keyType jwa.KeyType
+	var curve jwa.EllipticCurveAlgorithm
+	switch alg {
+	case jwa.HS256, jwa.HS384, jwa.HS512:
+		keyType = jwa.OctetSeq
+	case jwa.RS256, jwa.RS384, jwa.RS512, jwa.PS256, jwa.PS384, jwa.PS512:
+		keyType = jwa.RSA
+	case jwa.ES256:
+		keyType, curve = jwa.EC, jwa.P256
+	case jwa.ES384:
+		keyType, curve = jwa.EC, jwa.P384
+	case jwa.ES512:
+		keyType, curve = jwa.EC, jwa.P521
+	case jwa.EdDSA:
+		keyType, curve = jwa.OKP, jwa.Ed25519
+	default:
+		return UnsupportedAlgorithmError
+	}
+
+	if key.KeyType() != keyType {
+		return KeyTypeMismatchError
+	}
+	if len(curve) > 0 && keyCurve(key) != curve {
+		return KeyTypeMismatchError
+	}
+	return nil
+}
+
+func keyCurve(key jwk.Key) jwa.EllipticCurveAlgorithm {
+	switch k := key.(type) {
+	case jwk.ECDSAPrivateKey:
+		return k.Crv()
+	case jwk.ECDSAPublicKey:
+		return k.Crv()
+	case jwk.OKPPrivateKey:
+		return k.Crv()
+	case jwk.OKPPublicKey:
+		return k.Crv()
+	}
+	return jwa.InvalidEllipticCurve
+}
+
+func isHMAC(alg jwa.SignatureAlgorithm) bool {
+	return alg == jwa.HS256 || alg == jwa.HS384 || alg == jwa.HS512
+}
+
+func isPrivateKey(key jwk.Key) bool {
+	switch key.(type) {
+	case jwk.RSAPrivateKey, jwk.ECDSAPrivateKey, jwk.OKPPrivateKey:
+		return true
+	}
+	return false
+}
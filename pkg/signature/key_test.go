@@ -0,0 +1,138 @@
+package signature_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/signature"
+)
+
+var _ = Describe("Signature key", func() {
+	var (
+		privateKey *ecdsa.PrivateKey
+		pemKey     []byte
+		jwkKey     []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).To(BeNil())
+
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		Expect(err).To(BeNil())
+		pemKey = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+		key, err := jwk.FromRaw(privateKey)
+		Expect(err).To(BeNil())
+		jwkKey, err = json.Marshal(key)
+		Expect(err).To(BeNil())
+	})
+
+	Context("ParseKey", func() {
+		It("can parse PEM encoded key", func() {
+			key, err := signature.ParseKey(pemKey)
+			Expect(err).To(BeNil())
+			Expect(key.KeyType()).To(Equal(jwa.EC))
+		})
+
+		It("can parse JWK encoded key", func() {
+			key, err := signature.ParseKey(jwkKey)
+			Expect(err).To(BeNil())
+			Expect(key.KeyType()).To(Equal(jwa.EC))
+		})
+
+		It("returns error when key is empty", func() {
+			_, err := signature.ParseKey([]byte("  \n"))
+			Expect(err).To(Equal(signature.MissingKeyError))
+		})
+	})
+
+	Context("NewKey", func() {
+		for _, alg := range []string{"HS256", "HS384", "HS512"} {
+			alg := alg
+			It("creates "+alg+" key from the secret", func() {
+				key, err := signature.NewKey("", alg, []byte("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"))
+				Expect(err).To(BeNil())
+				Expect(key.ID).To(Equal(signature.DefaultSymmetricKeyID))
+				Expect(key.Algorithm).To(Equal(jwa.SignatureAlgorithm(alg)))
+				itSignsAndVerifies(key)
+			})
+		}
+
+		It("creates ES256 key from the PEM key", func() {
+			key, err := signature.NewKey("", "ES256", pemKey)
+			Expect(err).To(BeNil())
+			Expect(key.ID).ToNot(BeEmpty())
+			itSignsAndVerifies(key)
+		})
+
+		It("creates ES256 key from the JWK key", func() {
+			key, err := signature.NewKey("2022-08", "ES256", jwkKey)
+			Expect(err).To(BeNil())
+			Expect(key.ID).To(Equal("2022-08"))
+			itSignsAndVerifies(key)
+		})
+
+		It("creates RSA keys", func() {
+			privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).To(BeNil())
+			der := x509.MarshalPKCS1PrivateKey(privateKey)
+			material := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der})
+			for _, alg := range []string{"RS256", "PS256"} {
+				key, err := signature.NewKey("", alg, material)
+				Expect(err).To(BeNil())
+				itSignsAndVerifies(key)
+			}
+		})
+
+		It("creates EdDSA key", func() {
+			_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).To(BeNil())
+			der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+			Expect(err).To(BeNil())
+			key, err := signature.NewKey("", "EdDSA", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+			Expect(err).To(BeNil())
+			itSignsAndVerifies(key)
+		})
+
+		It("returns error when the key type does not match the algorithm", func() {
+			_, err := signature.NewKey("", "RS256", pemKey)
+			Expect(err).To(Equal(signature.KeyTypeMismatchError))
+		})
+
+		It("returns error when the curve does not match the algorithm", func() {
+			_, err := signature.NewKey("", "ES384", pemKey)
+			Expect(err).To(Equal(signature.KeyTypeMismatchError))
+		})
+
+		It("returns error when the algorithm is unsupported", func() {
+			_, err := signature.NewKey("", "none", pemKey)
+			Expect(err).To(Equal(signature.UnsupportedAlgorithmError))
+		})
+
+		It("returns error when key is missing", func() {
+			_, err := signature.NewKey("", "RS256", nil)
+			Expect(err).To(Equal(signature.MissingKeyError))
+		})
+	})
+})
+
+func itSignsAndVerifies(key signature.Key) {
+	keyring, err := signature.NewKeyring(key)
+	Expect(err).To(BeNil())
+	token, err := keyring.Sign([]byte("payload"))
+	Expect(err).To(BeNil())
+	verified, err := keyring.Verify(token)
+	Expect(err).To(BeNil())
+	Expect(verified).To(Equal([]byte("payload")))
+}
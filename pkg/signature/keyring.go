@@ -0,0 +1,302 @@
+package signature
+
+import (
+	"crypto"
+	"encoding/base64"
+	"errors"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	goJWS "github.com/lestrrat-go/jwx/v2/jws"
+	"sync"
+)
+
+// DefaultSymmetricKeyID is the kid given to a shared secret when no key ID is configured.
+// Asymmetric keys default to their RFC 7638 thumbprint instead.
+const DefaultSymmetricKeyID = "default"
+
+var (
+	UnknownKeyIDError        = errors.New("no key found for the token kid")
+	MissingKeyIDError        = errors.New("key ID is missing")
+	MissingKeyAlgorithmError = errors.New("key algorithm is missing")
+	DuplicateKeyIDError      = errors.New("key ID is used by more than one key")
+	SignatureCountError      = errors.New("token must have exactly one signature")
+)
+
+// Key is a signing or verification key identified by its kid.
+type Key struct {
+	ID        string
+	Algorithm jwa.SignatureAlgorithm
+	Key       jwk.Key
+}
+
+// NewKey creates the Key for the given algorithm.
+// For HS256, HS384 and HS512 the material is the shared secret, otherwise it is a PEM or JWK encoded key.
+func NewKey(kid string, algorithm string, material []byte) (Key, error) {
+	if len(material) == 0 {
+		return Key{}, MissingKeyError
+	}
+
+	alg := jwa.SignatureAlgorithm(algorithm)
+	var jwkKey jwk.Key
+	var err error
+	if isHMAC(alg) {
+		jwkKey, err = jwk.FromRaw(material)
+	} else {
+		jwkKey, err = ParseKey(material)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	if len(kid) == 0 {
+		kid, err = defaultKeyID(jwkKey)
+		if err != nil {
+			return Key{}, err
+		}
+	}
+	key := Key{ID: kid, Algorithm: alg, Key: jwkKey}
+	return key, key.validate()
+}
+
+// ParseKeySet parses a JWK Set. Every key must carry its "kid" and "alg" parameters.
+func ParseKeySet(data []byte) ([]Key, error) {
+	set, err := jwk.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Key, 0, set.Len())
+	for i := 0; i < set.Len(); i++ {
+		jwkKey, _ := set.Key(i)
+		if len(jwkKey.KeyID()) == 0 {
+			return nil, MissingKeyIDError
+		}
+		if jwkKey.Algorithm() == nil || len(jwkKey.Algorithm().String()) == 0 {
+			return nil, MissingKeyAlgorithmError
+		}
+		key := Key{
+			ID:        jwkKey.KeyID(),
+			Algorithm: jwa.SignatureAlgorithm(jwkKey.Algorithm().String()),
+			Key:       jwkKey,
+		}
+		if err := key.validate(); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (k Key) validate() error {
+	if len(k.ID) == 0 {
+		return MissingKeyIDError
+	}
+	return checkKeyType(k.Algorithm, k.Key)
+}
+
+func (k Key) canSign() bool {
+	return k.Key.KeyType() == jwa.OctetSeq || isPrivateKey(k.Key)
+}
+
+// verificationKey returns the public half of asymmetric keys and the secret itself for HMAC keys.
+func (k Key) verificationKey() (jwk.Key, error) {
+	if k.Key.KeyType() == jwa.OctetSeq {
+		return k.Key, nil
+	}
+	return k.Key.PublicKey()
+}
+
+func publishableKey(kid string, alg jwa.SignatureAlgorithm, publicKey jwk.Key) (jwk.Key, error) {
+	raw, err := jwk.PublicRawKeyOf(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwk.FromRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range map[string]interface{}{
+		jwk.KeyIDKey:     kid,
+		jwk.AlgorithmKey: alg,
+		jwk.KeyUsageKey:  jwk.ForSignature,
+	} {
+		if err := key.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func defaultKeyID(key jwk.Key) (string, error) {
+	if key.KeyType() == jwa.OctetSeq {
+		return DefaultSymmetricKeyID, nil
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// KeySetProvider publishes the public keys used to verify tokens.
+type KeySetProvider interface {
+	PublicKeySet() jwk.Set
+	// Algorithms are the algorithms of the keys, including the HMAC keys that are not published.
+	Algorithms() []string
+}
+
+type keyringEntry struct {
+	algorithm       jwa.SignatureAlgorithm
+	verificationKey jwk.Key
+}
+
+// Keyring signs with one active key, stamping its kid into the protected header,
+// and verifies with any of the active or retired keys picked by the token kid.
+// Tokens without kid, issued before key IDs were introduced, are tried against every key.
+type Keyring struct {
+	mu           sync.RWMutex
+	signingKey   Key
+	entries      map[string]keyringEntry
+	order        []string
+	publicKeySet jwk.Set
+}
+
+func NewKeyring(signingKey Key, retiredKeys ...Key) (*Keyring, error) {
+	k := &Keyring{}
+	if err := k.Reload(signingKey, retiredKeys...); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Reload atomically replaces the signing key and the retired verify-only keys.
+func (k *Keyring) Reload(signingKey Key, retiredKeys ...Key) error {
+	if err := signingKey.validate(); err != nil {
+		return err
+	}
+	if !signingKey.canSign() {
+		return PrivateKeyRequiredError
+	}
+
+	entries := make(map[string]keyringEntry, len(retiredKeys)+1)
+	order := make([]string, 0, len(retiredKeys)+1)
+	publicKeySet := jwk.NewSet()
+	for _, key := range append([]Key{signingKey}, retiredKeys...) {
+		if err := key.validate(); err != nil {
+			return err
+		}
+		if _, ok := entries[key.ID]; ok {
+			return DuplicateKeyIDError
+		}
+		verificationKey, err := key.verificationKey()
+		if err != nil {
+			return err
+		}
+		entries[key.ID] = keyringEntry{algorithm: key.Algorithm, verificationKey: verificationKey}
+		order = append(order, key.ID)
+
+		if key.Key.KeyType() != jwa.OctetSeq {
+			publicKey, err := publishableKey(key.ID, key.Algorithm, verificationKey)
+			if err != nil {
+				return err
+			}
+			if err := publicKeySet.AddKey(publicKey); err != nil {
+				return err
+			}
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.signingKey = signingKey
+	k.entries = entries
+	k.order = order
+	k.publicKeySet = publicKeySet
+	return nil
+}
+
+// PublicKeySet returns the public halves of the signing and retired keys.
+// Shared secrets of HMAC keys are never published.
+func (k *Keyring) PublicKeySet() jwk.Set {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.publicKeySet
+}
+
+// Algorithms returns the algorithms of the signing and retired keys, the signing key first.
+func (k *Keyring) Algorithms() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	algorithms := make([]string, 0, len(k.order))
+	seen := make(map[string]bool, len(k.order))
+	for _, kid := range k.order {
+		algorithm := k.entries[kid].algorithm.String()
+		if !seen[algorithm] {
+			seen[algorithm] = true
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+	return algorithms
+}
+
+// SigningKeyID returns the kid of the active signing key.
+func (k *Keyring) SigningKeyID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.signingKey.ID
+}
+
+func (k *Keyring) Sign(payload []byte) ([]byte, error) {
+	return k.Signer().Sign(payload)
+}
+
+// Signer returns the Signer of the active signing key, which keeps signing with that key even after Reload.
+func (k *Keyring) Signer() Signer {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return keySigner(k.signingKey)
+}
+
+type keySigner Key
+
+func (s keySigner) Sign(payload []byte) ([]byte, error) {
+	headers := goJWS.NewHeaders()
+	if err := headers.Set(goJWS.KeyIDKey, s.ID); err != nil {
+		return nil, err
+	}
+	return goJWS.Sign(payload, goJWS.WithKey(s.Algorithm, s.Key, goJWS.WithProtectedHeaders(headers)))
+}
+
+func (k *Keyring) Verify(token []byte) ([]byte, error) {
+	message, err := goJWS.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if len(message.Signatures()) != 1 {
+		return nil, SignatureCountError
+	}
+	kid := message.Signatures()[0].ProtectedHeaders().KeyID()
+
+	k.mu.RLock()
+	entries, order := k.entries, k.order
+	k.mu.RUnlock()
+
+	if len(kid) > 0 {
+		entry, ok := entries[kid]
+		if !ok {
+			return nil, UnknownKeyIDError
+		}
+		return goJWS.Verify(token, goJWS.WithKey(entry.algorithm, entry.verificationKey))
+	}
+
+	err = UnknownKeyIDError
+	for _, id := range order {
+		entry := entries[id]
+		var payload []byte
+		payload, err = goJWS.Verify(token, goJWS.WithKey(entry.algorithm, entry.verificationKey))
+		if err == nil {
+			return payload, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,171 @@
+package signature_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	goJWS "github.com/lestrrat-go/jwx/v2/jws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/signature"
+)
+
+var _ = Describe("Keyring", func() {
+	var (
+		keyring   *signature.Keyring
+		oldKey    signature.Key
+		newKey    signature.Key
+		oldSecret = "E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"
+		newSecret = "l0r3mIpsuMd0l0rS1tAm3tC0ns3ct3tu"
+		plaintext = []byte("Lorem ipsum dolor sit amet")
+		keyIDOf   = func(token []byte) string {
+			message, err := goJWS.Parse(token)
+			Expect(err).To(BeNil())
+			return message.Signatures()[0].ProtectedHeaders().KeyID()
+		}
+	)
+
+	BeforeEach(func() {
+		var err error
+		oldKey, err = signature.NewKey("", "HS256", []byte(oldSecret))
+		Expect(err).To(BeNil())
+		newKey, err = signature.NewKey("2022-08", "HS256", []byte(newSecret))
+		Expect(err).To(BeNil())
+		keyring, err = signature.NewKeyring(oldKey)
+		Expect(err).To(BeNil())
+	})
+
+	It("stamps the signing kid into the protected header", func() {
+		token, err := keyring.Sign(plaintext)
+		Expect(err).To(BeNil())
+		Expect(keyIDOf(token)).To(Equal(signature.DefaultSymmetricKeyID))
+
+		verified, err := keyring.Verify(token)
+		Expect(err).To(BeNil())
+		Expect(verified).To(Equal(plaintext))
+	})
+
+	It("verifies tokens signed by the retired key after rotation", func() {
+		oldToken, err := keyring.Sign(plaintext)
+		Expect(err).To(BeNil())
+
+		Expect(keyring.Reload(newKey, oldKey)).To(Succeed())
+		Expect(keyring.SigningKeyID()).To(Equal("2022-08"))
+
+		newToken, err := keyring.Sign(plaintext)
+		Expect(err).To(BeNil())
+		Expect(keyIDOf(newToken)).To(Equal("2022-08"))
+
+		for _, token := range [][]byte{oldToken, newToken} {
+			verified, err := keyring.Verify(token)
+			Expect(err).To(BeNil())
+			Expect(verified).To(Equal(plaintext))
+		}
+	})
+
+	It("keeps signing with the key of the signer after reload", func() {
+		signer := keyring.Signer()
+		Expect(keyring.Reload(newKey, oldKey)).To(Succeed())
+
+		token, err := signer.Sign(plaintext)
+		Expect(err).To(BeNil())
+		Expect(keyIDOf(token)).To(Equal(signature.DefaultSymmetricKeyID))
+	})
+
+	It("rejects tokens once the retired key is removed", func() {
+		oldToken, err := keyring.Sign(plaintext)
+		Expect(err).To(BeNil())
+
+		Expect(keyring.Reload(newKey)).To(Succeed())
+		_, err = keyring.Verify(oldToken)
+		Expect(err).To(Equal(signature.UnknownKeyIDError))
+	})
+
+	It("verifies legacy tokens without kid against every key", func() {
+		legacyToken, err := signature.NewJWS(oldSecret).Sign(plaintext)
+		Expect(err).To(BeNil())
+		Expect(keyring.Reload(newKey, oldKey)).To(Succeed())
+
+		verified, err := keyring.Verify(legacyToken)
+		Expect(err).To(BeNil())
+		Expect(verified).To(Equal(plaintext))
+	})
+
+	It("rejects duplicated key ID", func() {
+		Expect(keyring.Reload(oldKey, oldKey)).To(Equal(signature.DuplicateKeyIDError))
+	})
+
+	It("requires a private key to sign", func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+		publicKey, err := jwk.FromRaw(privateKey.Public())
+		Expect(err).To(BeNil())
+
+		err = keyring.Reload(signature.Key{ID: "public", Algorithm: jwa.EdDSA, Key: publicKey})
+		Expect(err).To(Equal(signature.PrivateKeyRequiredError))
+	})
+
+	Context("PublicKeySet", func() {
+		It("publishes the public keys without HMAC secrets", func() {
+			_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).To(BeNil())
+			jwkKey, err := jwk.FromRaw(privateKey)
+			Expect(err).To(BeNil())
+			Expect(keyring.Reload(signature.Key{ID: "ed-1", Algorithm: jwa.EdDSA, Key: jwkKey}, oldKey)).To(Succeed())
+
+			set := keyring.PublicKeySet()
+			Expect(set.Len()).To(Equal(1))
+			key, ok := set.LookupKeyID("ed-1")
+			Expect(ok).To(BeTrue())
+			Expect(key.Algorithm()).To(Equal(jwa.EdDSA))
+			Expect(key.KeyUsage()).To(Equal(string(jwk.ForSignature)))
+			_, isPrivate := key.(jwk.OKPPrivateKey)
+			Expect(isPrivate).To(BeFalse())
+			Expect(keyring.Algorithms()).To(Equal([]string{"EdDSA", oldKey.Algorithm.String()}))
+		})
+	})
+
+	Context("ParseKeySet", func() {
+		var key jwk.Key
+
+		BeforeEach(func() {
+			_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).To(BeNil())
+			key, err = jwk.FromRaw(privateKey.Public())
+			Expect(err).To(BeNil())
+		})
+
+		It("parses keys with kid and alg", func() {
+			Expect(key.Set(jwk.KeyIDKey, "ed-1")).To(Succeed())
+			Expect(key.Set(jwk.AlgorithmKey, jwa.EdDSA)).To(Succeed())
+			set, err := json.Marshal(map[string]interface{}{"keys": []jwk.Key{key}})
+			Expect(err).To(BeNil())
+
+			keys, err := signature.ParseKeySet(set)
+			Expect(err).To(BeNil())
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].ID).To(Equal("ed-1"))
+			Expect(keys[0].Algorithm).To(Equal(jwa.EdDSA))
+		})
+
+		It("requires kid", func() {
+			Expect(key.Set(jwk.AlgorithmKey, jwa.EdDSA)).To(Succeed())
+			set, err := json.Marshal(map[string]interface{}{"keys": []jwk.Key{key}})
+			Expect(err).To(BeNil())
+
+			_, err = signature.ParseKeySet(set)
+			Expect(err).To(Equal(signature.MissingKeyIDError))
+		})
+
+		It("requires alg", func() {
+			Expect(key.Set(jwk.KeyIDKey, "ed-1")).To(Succeed())
+			set, err := json.Marshal(map[string]interface{}{"keys": []jwk.Key{key}})
+			Expect(err).To(BeNil())
+
+			_, err = signature.ParseKeySet(set)
+			Expect(err).To(Equal(signature.MissingKeyAlgorithmError))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package token
+
+import (
+	"github.com/thetkpark/heimdall/pkg/config"
+	"sync"
+)
+
+// ParseBatch parses the tokens concurrently with at most workers goroutines.
+// The payload and the error of each token are at the same index.
+func ParseBatch(mng Manager, tokens []string, workers int) ([]*config.Payload, []error) {
+	payloads := make([]*config.Payload, len(tokens))
+	errs := make([]error, len(tokens))
+	if workers > len(tokens) {
+		workers = len(tokens)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				payloads[i], errs[i] = mng.Parse(tokens[i])
+			}
+		}()
+	}
+	for i := range tokens {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return payloads, errs
+}
@@ -0,0 +1,58 @@
+package token_test
+
+import (
+	"encoding/json"
+	"fmt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"time"
+)
+
+var _ = Describe("ParseBatch", func() {
+	var (
+		tokenManager token.Manager
+		tokens       []string
+	)
+
+	BeforeEach(func() {
+		tokenManager = token.NewTokenManager(signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"), nil)
+		tokens = nil
+		for i := 0; i < 10; i++ {
+			expiredAt := time.Now().Add(time.Minute)
+			if i%3 == 0 {
+				expiredAt = time.Now().Add(-time.Minute)
+			}
+			tokenString, err := tokenManager.Generate(config.Payload{
+				CustomPayload:   config.CustomPayload{"user_id": json.Number(fmt.Sprint(i))},
+				MetadataPayload: config.MetadataPayload{ExpiredAt: config.NewNumericDate(expiredAt)},
+			})
+			Expect(err).To(BeNil())
+			tokens = append(tokens, tokenString)
+		}
+		tokens = append(tokens, "invalid.token.string")
+	})
+
+	It("parses every token in order", func() {
+		payloads, errs := token.ParseBatch(tokenManager, tokens, 3)
+		Expect(payloads).To(HaveLen(len(tokens)))
+		Expect(errs).To(HaveLen(len(tokens)))
+		for i := 0; i < 10; i++ {
+			if i%3 == 0 {
+				Expect(errs[i]).To(MatchError(token.TokenExpiredError))
+				Expect(payloads[i]).To(BeNil())
+				continue
+			}
+			Expect(errs[i]).To(BeNil())
+			Expect(payloads[i].CustomPayload).To(HaveKeyWithValue("user_id", json.Number(fmt.Sprint(i))))
+		}
+		Expect(errs[10]).ToNot(BeNil())
+	})
+
+	It("parses with a single worker when workers is not positive", func() {
+		_, errs := token.ParseBatch(tokenManager, tokens[1:2], 0)
+		Expect(errs).To(Equal([]error{nil}))
+	})
+})
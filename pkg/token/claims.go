@@ -0,0 +1,122 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"sort"
+)
+
+// UserIDClaim is the custom claim that the "sub" claim is derived from.
+const UserIDClaim = "user_id"
+
+// ScopeClaim is the space separated scopes of the token, as in RFC 8693.
+const ScopeClaim = "scope"
+
+// DefaultClaimsSchema requires the user_id claim, as the payload of the previous versions did.
+const DefaultClaimsSchema = `{
+	"type": "object",
+	"properties": {
+		"user_id": {"type": "integer", "minimum": 1}
+	},
+	"required": ["user_id"]
+}`
+
+const claimsSchemaURL = "claims.schema.json"
+
+var ReservedClaimError = errors.New("claim name is reserved")
+
+// ClaimsError is returned by Generate when the custom claims are rejected.
+type ClaimsError struct {
+	Err error
+}
+
+func (e *ClaimsError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ClaimsError) Unwrap() error {
+	return e.Err
+}
+
+// ClaimsSchema is the JSON schema that the custom claims must conform to.
+type ClaimsSchema struct {
+	schema *jsonschema.Schema
+}
+
+func NewClaimsSchema(data []byte) (*ClaimsSchema, error) {
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(claimsSchemaURL, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	schema, err := compiler.Compile(claimsSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	return &ClaimsSchema{schema: schema}, nil
+}
+
+// Validate validates the JSON encoding of the claims against the schema.
+func (s *ClaimsSchema) Validate(claims config.CustomPayload) error {
+	rawClaims, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+	document, err := config.DecodeCustomPayload(bytes.NewReader(rawClaims))
+	if err != nil {
+		return err
+	}
+	return s.schema.Validate(map[string]interface{}(document))
+}
+
+// Properties returns the sorted names of the properties of the claims, including those of its $ref and allOf schemas.
+func (s *ClaimsSchema) Properties() []string {
+	names := make(map[string]bool)
+	collectProperties(s.schema, names)
+	properties := make([]string, 0, len(names))
+	for name := range names {
+		properties = append(properties, name)
+	}
+	sort.Strings(properties)
+	return properties
+}
+
+func collectProperties(schema *jsonschema.Schema, names map[string]bool) {
+	if schema == nil {
+		return
+	}
+	for name := range schema.Properties {
+		names[name] = true
+	}
+	collectProperties(schema.Ref, names)
+	for _, subschema := range schema.AllOf {
+		collectProperties(subschema, names)
+	}
+}
+
+// WithClaimsSchema rejects generating tokens whose custom claims do not conform to the schema.
+func WithClaimsSchema(schema *ClaimsSchema) Option {
+	return func(o *options) {
+		o.claimsSchema = schema
+	}
+}
+
+func (o options) validateClaims(claims config.CustomPayload) error {
+	for _, names := range [][]string{config.RegisteredClaimNames, legacyClaimNames, refreshClaimNames, exchangeClaimNames} {
+		for _, name := range names {
+			if _, ok := claims[name]; ok {
+				return &ClaimsError{Err: fmt.Errorf("%w: %s", ReservedClaimError, name)}
+			}
+		}
+	}
+	if o.claimsSchema == nil {
+		return nil
+	}
+	if err := o.claimsSchema.Validate(claims); err != nil {
+		return &ClaimsError{Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package token_test
+
+import (
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"time"
+)
+
+var _ = Describe("Custom claims", func() {
+	var (
+		schema       *token.ClaimsSchema
+		tokenManager token.Manager
+		payload      config.Payload
+	)
+
+	BeforeEach(func() {
+		var err error
+		schema, err = token.NewClaimsSchema([]byte(token.DefaultClaimsSchema))
+		Expect(err).To(BeNil())
+		payload = config.Payload{
+			CustomPayload: config.CustomPayload{
+				"user_id": json.Number("99"),
+				"roles":   []interface{}{"admin", "editor"},
+				"tenant":  map[string]interface{}{"id": "acme", "plan": json.Number("3")},
+			},
+			MetadataPayload: config.MetadataPayload{
+				ExpiredAt: config.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		mng := token.NewTokenManager(signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"), nil, token.WithClaimsSchema(schema))
+		mng.SetFormat(token.StandardFormat)
+		tokenManager = mng
+	})
+
+	It("rejects invalid schema", func() {
+		_, err := token.NewClaimsSchema([]byte(`{"type": 1}`))
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("returns the properties of the schema", func() {
+		Expect(schema.Properties()).To(Equal([]string{"user_id"}))
+		schema, err := token.NewClaimsSchema([]byte(`{
+			"$defs": {"user": {"properties": {"user_id": {}, "email": {}}}},
+			"allOf": [{"$ref": "#/$defs/user"}, {"properties": {"roles": {}}}],
+			"properties": {"tenant": {}}
+		}`))
+		Expect(err).To(BeNil())
+		Expect(schema.Properties()).To(Equal([]string{"email", "roles", "tenant", "user_id"}))
+	})
+
+	It("round trips arbitrary claims", func() {
+		tokenString, err := tokenManager.Generate(payload)
+		Expect(err).To(BeNil())
+		retrievedPayload, err := tokenManager.Parse(tokenString)
+		Expect(err).To(BeNil())
+		Expect(retrievedPayload.CustomPayload).To(Equal(payload.CustomPayload))
+		Expect(retrievedPayload.Subject).To(Equal("99"))
+	})
+
+	It("rejects claims that do not conform to the schema", func() {
+		delete(payload.CustomPayload, "user_id")
+		_, err := tokenManager.Generate(payload)
+		var claimsErr *token.ClaimsError
+		Expect(errors.As(err, &claimsErr)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("user_id"))
+	})
+
+	It("generates a batch with the error of each claims", func() {
+		invalidPayload := config.Payload{CustomPayload: config.CustomPayload{"email": "user@example.com"}}
+		tokens, errs := tokenManager.GenerateBatch([]config.Payload{payload, invalidPayload, payload})
+		Expect(tokens).To(HaveLen(3))
+		Expect(errs).To(HaveLen(3))
+		for _, i := range []int{0, 2} {
+			Expect(errs[i]).To(BeNil())
+			retrievedPayload, err := tokenManager.Parse(tokens[i])
+			Expect(err).To(BeNil())
+			Expect(retrievedPayload.CustomPayload).To(Equal(payload.CustomPayload))
+		}
+		Expect(tokens[0]).ToNot(Equal(tokens[2]))
+		var claimsErr *token.ClaimsError
+		Expect(errors.As(errs[1], &claimsErr)).To(BeTrue())
+		Expect(tokens[1]).To(BeEmpty())
+	})
+
+	It("rejects reserved claims", func() {
+		payload.CustomPayload["exp"] = json.Number("0")
+		_, err := tokenManager.Generate(payload)
+		var claimsErr *token.ClaimsError
+		Expect(errors.As(err, &claimsErr)).To(BeTrue())
+		Expect(errors.Is(err, token.ReservedClaimError)).To(BeTrue())
+	})
+
+	When("Schema is custom", func() {
+		BeforeEach(func() {
+			var err error
+			schema, err = token.NewClaimsSchema([]byte(`{
+				"type": "object",
+				"properties": {"email": {"type": "string", "format": "email"}},
+				"required": ["email"]
+			}`))
+			Expect(err).To(BeNil())
+			payload.CustomPayload = config.CustomPayload{"email": "user@example.com"}
+		})
+
+		It("accepts claims without user_id", func() {
+			tokenString, err := tokenManager.Generate(payload)
+			Expect(err).To(BeNil())
+			retrievedPayload, err := tokenManager.Parse(tokenString)
+			Expect(err).To(BeNil())
+			Expect(retrievedPayload.CustomPayload).To(Equal(payload.CustomPayload))
+			Expect(retrievedPayload.Subject).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package token
+
+import (
+	"github.com/thetkpark/heimdall/pkg/config"
+)
+
+// ActorClaim is the party that the token is issued to act on behalf of its subject, as in RFC 8693.
+// Its own "act" claim is the previous actor, so the nested claims are the delegation chain, latest first.
+const ActorClaim = "act"
+
+var exchangeClaimNames = []string{ActorClaim}
+
+// ExchangeManager issues the delegated tokens of RFC 8693 token exchange.
+type ExchangeManager interface {
+	// Delegate generates the token of the payload that the actor acts on behalf of the subject of the parsed subject token.
+	// The token inherits the subject and the refresh token family of the subject token, and never outlives it.
+	Delegate(subject *config.Payload, actor string, payload config.Payload) (string, error)
+}
+
+// NewExchangeManager returns the ExchangeManager of the manager.
+func NewExchangeManager(mng *manager) *exchangeManager {
+	return &exchangeManager{tokenManager: mng}
+}
+
+type exchangeManager struct {
+	tokenManager *manager
+}
+
+func (m exchangeManager) Delegate(subject *config.Payload, actor string, payload config.Payload) (string, error) {
+	if err := m.tokenManager.validateClaims(payload.CustomPayload); err != nil {
+		return "", err
+	}
+
+	claims := config.CustomPayload{}
+	for name, value := range payload.CustomPayload {
+		claims[name] = value
+	}
+	actorClaim := map[string]interface{}{"sub": actor}
+	if previous, ok := subject.CustomPayload[ActorClaim]; ok {
+		actorClaim[ActorClaim] = previous
+	}
+	claims[ActorClaim] = actorClaim
+	if family, ok := subject.CustomPayload[FamilyClaim]; ok {
+		claims[FamilyClaim] = family
+	}
+	payload.CustomPayload = claims
+
+	payload.Subject = Subject(subject)
+	if subject.ExpiredAt != nil && (payload.ExpiredAt == nil || payload.ExpiredAt.After(subject.ExpiredAt.Time)) {
+		payload.ExpiredAt = subject.ExpiredAt
+	}
+	return m.tokenManager.generate(m.tokenManager.signatureManager, payload)
+}
@@ -0,0 +1,81 @@
+package token_test
+
+import (
+	"context"
+	"encoding/json"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"time"
+)
+
+var _ = Describe("Exchange Manager", func() {
+	var (
+		store           revocation.Store
+		tokenManager    token.Manager
+		exchangeManager token.ExchangeManager
+		subject         *config.Payload
+	)
+
+	BeforeEach(func() {
+		store = revocation.NewMemoryStore()
+		mng := token.NewTokenManager(signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"), nil, token.WithRevocationStore(store))
+		mng.SetFormat(token.StandardFormat)
+		tokenManager = mng
+		exchangeManager = token.NewExchangeManager(mng)
+		subject = &config.Payload{
+			CustomPayload: config.CustomPayload{token.FamilyClaim: "family"},
+			MetadataPayload: config.MetadataPayload{
+				Subject:   "99",
+				ExpiredAt: config.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		}
+	})
+
+	It("delegates the subject to the actor", func() {
+		delegated, err := exchangeManager.Delegate(subject, "billing", config.Payload{
+			CustomPayload:   config.CustomPayload{"scope": "read"},
+			MetadataPayload: config.MetadataPayload{ExpiredAt: config.NewNumericDate(time.Now().Add(time.Hour))},
+		})
+		Expect(err).To(BeNil())
+
+		payload, err := tokenManager.Parse(delegated)
+		Expect(err).To(BeNil())
+		Expect(payload.Subject).To(Equal("99"))
+		Expect(payload.CustomPayload).To(HaveKeyWithValue("scope", "read"))
+		Expect(payload.CustomPayload).To(HaveKeyWithValue(token.ActorClaim, map[string]interface{}{"sub": "billing"}))
+		Expect(payload.ExpiredAt).To(Equal(subject.ExpiredAt))
+	})
+
+	It("nests the actor of the subject token", func() {
+		subject.CustomPayload[token.ActorClaim] = map[string]interface{}{"sub": "gateway"}
+		delegated, err := exchangeManager.Delegate(subject, "billing", config.Payload{})
+		Expect(err).To(BeNil())
+
+		payload, err := tokenManager.Parse(delegated)
+		Expect(err).To(BeNil())
+		Expect(payload.CustomPayload).To(HaveKeyWithValue(token.ActorClaim, map[string]interface{}{
+			"sub": "billing",
+			"act": map[string]interface{}{"sub": "gateway"},
+		}))
+	})
+
+	It("revokes the token with the family of the subject token", func() {
+		delegated, err := exchangeManager.Delegate(subject, "billing", config.Payload{})
+		Expect(err).To(BeNil())
+		Expect(store.RevokeToken(context.Background(), "family", time.Now().Add(time.Hour))).To(Succeed())
+
+		_, err = tokenManager.Parse(delegated)
+		Expect(err).To(MatchError(token.TokenRevokedError))
+	})
+
+	It("rejects reserved claims", func() {
+		_, err := exchangeManager.Delegate(subject, "billing", config.Payload{
+			CustomPayload: config.CustomPayload{token.ActorClaim: map[string]interface{}{"sub": "admin"}, "user_id": json.Number("1")},
+		})
+		Expect(err).To(MatchError(token.ReservedClaimError))
+	})
+})
@@ -0,0 +1,126 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"time"
+)
+
+// Format is the wire format of the token claims.
+type Format string
+
+const (
+	// LegacyFormat emits and accepts only the issued_at/expired_at RFC 3339 claims and the jti claim.
+	LegacyFormat Format = "legacy"
+	// StandardFormat emits and accepts only the RFC 7519 registered claims.
+	StandardFormat Format = "standard"
+	// CompatFormat emits the RFC 7519 registered claims and still accepts the legacy claims during migration.
+	CompatFormat Format = "compat"
+)
+
+var (
+	UnknownFormatError = errors.New("unknown token format")
+	LegacyClaimsError  = errors.New("token has legacy issued_at/expired_at claims")
+)
+
+var legacyClaimNames = []string{"issued_at", "expired_at"}
+
+// legacyUnboundedExpiry is the margin under which a legacy expired_at that follows issued_at means the token never expires.
+// Before TOKEN_VALID_TIME was enforced, tokens generated without it had expired_at set to issued_at.
+const legacyUnboundedExpiry = time.Second
+
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case LegacyFormat, StandardFormat, CompatFormat:
+		return Format(format), nil
+	}
+	return "", UnknownFormatError
+}
+
+type legacyMetadata struct {
+	IssuedAt  *time.Time `json:"issued_at,omitempty"`
+	ExpiredAt *time.Time `json:"expired_at,omitempty"`
+}
+
+func (l legacyMetadata) isEmpty() bool {
+	return l.IssuedAt == nil && l.ExpiredAt == nil
+}
+
+// expiredAt returns the expiry of the legacy claims, which is nil for the tokens generated without TOKEN_VALID_TIME.
+func (l legacyMetadata) expiredAt() *config.NumericDate {
+	if l.ExpiredAt == nil {
+		return nil
+	}
+	if l.IssuedAt != nil {
+		if validTime := l.ExpiredAt.Sub(*l.IssuedAt); validTime >= 0 && validTime < legacyUnboundedExpiry {
+			return nil
+		}
+	}
+	return &config.NumericDate{Time: *l.ExpiredAt}
+}
+
+// encodeClaims marshals the payload into the wire format.
+func (f Format) encodeClaims(payload config.Payload) ([]byte, error) {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil || f != LegacyFormat {
+		return rawPayload, err
+	}
+
+	claims, err := config.DecodeCustomPayload(bytes.NewReader(rawPayload))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range config.RegisteredClaimNames {
+		// jti is kept so that legacy tokens can be revoked
+		if name != "jti" {
+			delete(claims, name)
+		}
+	}
+	if payload.IssuedAt != nil {
+		claims["issued_at"] = payload.IssuedAt.Time
+	}
+	if payload.ExpiredAt != nil {
+		claims["expired_at"] = payload.ExpiredAt.Time
+	}
+	return json.Marshal(claims)
+}
+
+// decodeClaims unmarshals the wire format into the payload.
+func (f Format) decodeClaims(rawPayload []byte) (*config.Payload, error) {
+	var payload config.Payload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return nil, err
+	}
+	var legacy legacyMetadata
+	if err := json.Unmarshal(rawPayload, &legacy); err != nil {
+		return nil, err
+	}
+	for _, name := range legacyClaimNames {
+		delete(payload.CustomPayload, name)
+	}
+
+	switch f {
+	case LegacyFormat:
+		payload.MetadataPayload = config.MetadataPayload{ID: payload.ID}
+		if legacy.IssuedAt != nil {
+			payload.IssuedAt = &config.NumericDate{Time: *legacy.IssuedAt}
+		}
+		payload.ExpiredAt = legacy.expiredAt()
+	case StandardFormat:
+		if !legacy.isEmpty() {
+			return nil, LegacyClaimsError
+		}
+	case CompatFormat:
+		if payload.IssuedAt == nil && legacy.IssuedAt != nil {
+			payload.IssuedAt = &config.NumericDate{Time: *legacy.IssuedAt}
+		}
+		if payload.ExpiredAt == nil {
+			payload.ExpiredAt = legacy.expiredAt()
+		}
+	default:
+		return nil, UnknownFormatError
+	}
+	return &payload, nil
+}
@@ -0,0 +1,238 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"strconv"
+	"time"
+)
+
+const (
+	// TokenUseClaim is RefreshTokenUse in the refresh tokens, which are rejected by Parse.
+	TokenUseClaim   = "token_use"
+	RefreshTokenUse = "refresh"
+	// FamilyClaim is the jti of the first refresh token of the rotations that the token is issued by.
+	FamilyClaim = "fam"
+	// AccessTTLClaim is the seconds that the access tokens of the refresh token are valid for,
+	// so the pairs that it is exchanged for keep the lifetime of the first access token.
+	AccessTTLClaim = "access_ttl"
+)
+
+var refreshClaimNames = []string{TokenUseClaim, FamilyClaim, AccessTTLClaim}
+
+var (
+	TokenUseError           = errors.New("token is not valid for this use")
+	RefreshTokenReusedError = errors.New("refresh token is reused")
+	RefreshStoreError       = errors.New("refresh tokens require a revocation store")
+)
+
+// TokenPair is the access token and the refresh token that a new pair is exchanged for.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// RefreshManager issues the access tokens together with the refresh tokens.
+// Each refresh token can only be used once. Using it again revokes every token of its family,
+// which are the tokens issued by refreshing it and its predecessors.
+type RefreshManager interface {
+	Generate(payload config.Payload) (*TokenPair, error)
+	// GenerateBatch generates the pair of each payload, signing them all with the same key.
+	// The error of each payload is at the same index, and its pair is nil.
+	GenerateBatch(payloads []config.Payload) ([]*TokenPair, []error)
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+	// ParseRefreshToken parses the refresh token, which Parse rejects with TokenUseError.
+	ParseRefreshToken(refreshToken string) (*config.Payload, error)
+	// Revoke revokes the parsed refresh token together with every token of its family.
+	Revoke(ctx context.Context, payload *config.Payload) error
+}
+
+// NewRefreshManager returns the RefreshManager of the manager, whose revocation store keeps the used refresh tokens.
+// Access tokens are valid for validTime, or forever when it is zero, and refresh tokens are valid for refreshValidTime.
+func NewRefreshManager(mng *manager, validTime, refreshValidTime time.Duration) (*refreshManager, error) {
+	if mng.revocationStore == nil {
+		return nil, RefreshStoreError
+	}
+	return &refreshManager{
+		tokenManager:     mng,
+		validTime:        validTime,
+		refreshValidTime: refreshValidTime,
+	}, nil
+}
+
+type refreshManager struct {
+	tokenManager     *manager
+	validTime        time.Duration
+	refreshValidTime time.Duration
+}
+
+func (m refreshManager) Generate(payload config.Payload) (*TokenPair, error) {
+	if err := m.tokenManager.validateClaims(payload.CustomPayload); err != nil {
+		return nil, err
+	}
+	return m.generatePair(m.tokenManager.signatureManager, payload, "")
+}
+
+func (m refreshManager) GenerateBatch(payloads []config.Payload) ([]*TokenPair, []error) {
+	signer := m.tokenManager.signer()
+	pairs := make([]*TokenPair, len(payloads))
+	errs := make([]error, len(payloads))
+	for i, payload := range payloads {
+		if err := m.tokenManager.validateClaims(payload.CustomPayload); err != nil {
+			errs[i] = err
+			continue
+		}
+		pairs[i], errs[i] = m.generatePair(signer, payload, "")
+	}
+	return pairs, errs
+}
+
+func (m refreshManager) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	payload, err := m.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	family := payload.CustomPayload[FamilyClaim].(string)
+
+	store := m.tokenManager.revocationStore
+	used, err := store.UseToken(ctx, payload.ID, payload.ExpiredAt.Add(m.tokenManager.leeway))
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		if err := store.RevokeToken(ctx, family, m.familyExpiry(payload)); err != nil {
+			return nil, err
+		}
+		return nil, &ValidationError{Err: RefreshTokenReusedError}
+	}
+
+	claims := config.CustomPayload{}
+	for name, value := range payload.CustomPayload {
+		claims[name] = value
+	}
+	for _, name := range refreshClaimNames {
+		delete(claims, name)
+	}
+	refreshed := config.Payload{
+		CustomPayload:   claims,
+		MetadataPayload: config.MetadataPayload{Subject: payload.Subject, Audience: payload.Audience},
+	}
+	if accessTTL, ok := accessTTL(payload); ok {
+		now := m.tokenManager.now()
+		refreshed.IssuedAt = config.NewNumericDate(now)
+		refreshed.ExpiredAt = config.NewNumericDate(now.Add(accessTTL))
+	}
+	return m.generatePair(m.tokenManager.signatureManager, refreshed, family)
+}
+
+func (m refreshManager) ParseRefreshToken(refreshToken string) (*config.Payload, error) {
+	payload, err := m.tokenManager.parse(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	family, _ := payload.CustomPayload[FamilyClaim].(string)
+	if !isRefreshToken(payload) || len(family) == 0 || len(payload.ID) == 0 || payload.ExpiredAt == nil {
+		return nil, &ValidationError{Err: TokenUseError}
+	}
+	return payload, nil
+}
+
+func (m refreshManager) Revoke(ctx context.Context, payload *config.Payload) error {
+	store := m.tokenManager.revocationStore
+	if err := store.RevokeToken(ctx, payload.ID, payload.ExpiredAt.Add(m.tokenManager.leeway)); err != nil {
+		return err
+	}
+	family, _ := payload.CustomPayload[FamilyClaim].(string)
+	return store.RevokeToken(ctx, family, m.familyExpiry(payload))
+}
+
+// generatePair issues the pair of the payload in the family, or in a new family when it is empty.
+func (m refreshManager) generatePair(signer signature.Signer, payload config.Payload, family string) (*TokenPair, error) {
+	now := m.tokenManager.now()
+	refreshID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+	if len(family) == 0 {
+		family = refreshID
+	}
+
+	if payload.IssuedAt == nil {
+		payload.IssuedAt = config.NewNumericDate(now)
+	}
+	if payload.ExpiredAt == nil && m.validTime > 0 {
+		payload.ExpiredAt = config.NewNumericDate(now.Add(m.validTime))
+	}
+
+	refreshClaims := config.CustomPayload{}
+	for name, value := range payload.CustomPayload {
+		refreshClaims[name] = value
+	}
+	refreshClaims[TokenUseClaim] = RefreshTokenUse
+	refreshClaims[FamilyClaim] = family
+	if payload.ExpiredAt != nil {
+		refreshClaims[AccessTTLClaim] = int64(payload.ExpiredAt.Sub(payload.IssuedAt.Time) / time.Second)
+	}
+	refreshToken, err := m.tokenManager.generate(signer, config.Payload{
+		CustomPayload: refreshClaims,
+		MetadataPayload: config.MetadataPayload{
+			Subject:   payload.Subject,
+			Audience:  payload.Audience,
+			IssuedAt:  config.NewNumericDate(now),
+			ExpiredAt: config.NewNumericDate(now.Add(m.refreshValidTime)),
+			ID:        refreshID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessClaims := config.CustomPayload{}
+	for name, value := range payload.CustomPayload {
+		accessClaims[name] = value
+	}
+	accessClaims[FamilyClaim] = family
+	payload.CustomPayload = accessClaims
+	accessToken, err := m.tokenManager.generate(signer, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// familyExpiry is when every token of the family of the refresh token issued so far is expired,
+// or zero when its access tokens never expire.
+func (m refreshManager) familyExpiry(payload *config.Payload) time.Time {
+	accessValidTime, ok := accessTTL(payload)
+	if !ok {
+		if m.validTime <= 0 {
+			return time.Time{}
+		}
+		accessValidTime = m.validTime
+	}
+	validTime := m.refreshValidTime
+	if accessValidTime > validTime {
+		validTime = accessValidTime
+	}
+	return m.tokenManager.now().Add(validTime + m.tokenManager.leeway)
+}
+
+// accessTTL returns the lifetime of the access tokens of the refresh token, which is not set when they never expire.
+func accessTTL(payload *config.Payload) (time.Duration, bool) {
+	value, ok := payload.CustomPayload[AccessTTLClaim]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(fmt.Sprint(value), 10, 64)
+	if err != nil || seconds <= 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func isRefreshToken(payload *config.Payload) bool {
+	return payload.CustomPayload[TokenUseClaim] == RefreshTokenUse
+}
@@ -0,0 +1,172 @@
+package token_test
+
+import (
+	"context"
+	"encoding/json"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"time"
+)
+
+var _ = Describe("Refresh Manager", func() {
+	var (
+		ctx            = context.Background()
+		tokenManager   token.Manager
+		refreshManager token.RefreshManager
+		payload        config.Payload
+		pair           *token.TokenPair
+	)
+
+	BeforeEach(func() {
+		mng := token.NewTokenManager(signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"), nil, token.WithRevocationStore(revocation.NewMemoryStore()))
+		mng.SetFormat(token.StandardFormat)
+		tokenManager = mng
+		var err error
+		refreshManager, err = token.NewRefreshManager(mng, time.Minute, time.Hour)
+		Expect(err).To(BeNil())
+		payload = config.Payload{CustomPayload: config.CustomPayload{"user_id": json.Number("99")}}
+		pair, err = refreshManager.Generate(payload)
+		Expect(err).To(BeNil())
+	})
+
+	It("requires a revocation store", func() {
+		_, err := token.NewRefreshManager(token.NewTokenManager(signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4"), nil), time.Minute, time.Hour)
+		Expect(err).To(Equal(token.RefreshStoreError))
+	})
+
+	It("issues an access token of the payload", func() {
+		accessPayload, err := tokenManager.Parse(pair.AccessToken)
+		Expect(err).To(BeNil())
+		Expect(accessPayload.CustomPayload).To(HaveKeyWithValue("user_id", json.Number("99")))
+		Expect(accessPayload.ExpiredAt.Time).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+	})
+
+	It("rejects the refresh token as access token", func() {
+		_, err := tokenManager.Parse(pair.RefreshToken)
+		Expect(err).To(MatchError(token.TokenUseError))
+	})
+
+	It("rejects the access token as refresh token", func() {
+		_, err := refreshManager.Refresh(ctx, pair.AccessToken)
+		Expect(err).To(MatchError(token.TokenUseError))
+	})
+
+	It("generates a batch of pairs in their own families", func() {
+		pairs, errs := refreshManager.GenerateBatch([]config.Payload{payload, payload})
+		Expect(errs).To(Equal([]error{nil, nil}))
+		_, err := refreshManager.Refresh(ctx, pairs[0].RefreshToken)
+		Expect(err).To(BeNil())
+		_, err = refreshManager.Refresh(ctx, pairs[0].RefreshToken)
+		Expect(err).To(MatchError(token.RefreshTokenReusedError))
+		_, err = tokenManager.Parse(pairs[1].AccessToken)
+		Expect(err).To(BeNil())
+	})
+
+	It("rejects reserved claims", func() {
+		payload.CustomPayload[token.FamilyClaim] = "family"
+		_, err := refreshManager.Generate(payload)
+		Expect(err).To(MatchError(token.ReservedClaimError))
+	})
+
+	It("rotates the refresh token", func() {
+		newPair, err := refreshManager.Refresh(ctx, pair.RefreshToken)
+		Expect(err).To(BeNil())
+		Expect(newPair.RefreshToken).ToNot(Equal(pair.RefreshToken))
+
+		accessPayload, err := tokenManager.Parse(newPair.AccessToken)
+		Expect(err).To(BeNil())
+		Expect(accessPayload.CustomPayload).To(HaveKeyWithValue("user_id", json.Number("99")))
+		Expect(accessPayload.CustomPayload).ToNot(HaveKey(token.TokenUseClaim))
+		Expect(accessPayload.Subject).To(Equal("99"))
+
+		_, err = refreshManager.Refresh(ctx, newPair.RefreshToken)
+		Expect(err).To(BeNil())
+	})
+
+	It("keeps the audience of the pair", func() {
+		payload.Audience = config.Audience{"billing"}
+		pair, err := refreshManager.Generate(payload)
+		Expect(err).To(BeNil())
+		newPair, err := refreshManager.Refresh(ctx, pair.RefreshToken)
+		Expect(err).To(BeNil())
+
+		accessPayload, err := tokenManager.Parse(newPair.AccessToken)
+		Expect(err).To(BeNil())
+		Expect(accessPayload.Audience).To(Equal(config.Audience{"billing"}))
+		refreshPayload, err := refreshManager.ParseRefreshToken(newPair.RefreshToken)
+		Expect(err).To(BeNil())
+		Expect(refreshPayload.Audience).To(Equal(config.Audience{"billing"}))
+	})
+
+	It("keeps the lifetime of the access token of the pair", func() {
+		now := time.Now()
+		payload.IssuedAt = config.NewNumericDate(now)
+		payload.ExpiredAt = config.NewNumericDate(now.Add(10 * time.Minute))
+		pair, err := refreshManager.Generate(payload)
+		Expect(err).To(BeNil())
+		for i := 0; i < 2; i++ {
+			pair, err = refreshManager.Refresh(ctx, pair.RefreshToken)
+			Expect(err).To(BeNil())
+			accessPayload, err := tokenManager.Parse(pair.AccessToken)
+			Expect(err).To(BeNil())
+			Expect(accessPayload.ExpiredAt.Time).To(BeTemporally("~", time.Now().Add(10*time.Minute), time.Second))
+			Expect(accessPayload.CustomPayload).ToNot(HaveKey(token.AccessTTLClaim))
+		}
+	})
+
+	It("parses the refresh token", func() {
+		refreshPayload, err := refreshManager.ParseRefreshToken(pair.RefreshToken)
+		Expect(err).To(BeNil())
+		Expect(refreshPayload.CustomPayload).To(HaveKey(token.FamilyClaim))
+
+		_, err = refreshManager.ParseRefreshToken(pair.AccessToken)
+		Expect(err).To(MatchError(token.TokenUseError))
+	})
+
+	It("revokes the refresh token and its family", func() {
+		newPair, err := refreshManager.Refresh(ctx, pair.RefreshToken)
+		Expect(err).To(BeNil())
+		refreshPayload, err := refreshManager.ParseRefreshToken(newPair.RefreshToken)
+		Expect(err).To(BeNil())
+		Expect(refreshManager.Revoke(ctx, refreshPayload)).To(Succeed())
+
+		_, err = refreshManager.Refresh(ctx, newPair.RefreshToken)
+		Expect(err).To(MatchError(token.TokenRevokedError))
+		_, err = tokenManager.Parse(newPair.AccessToken)
+		Expect(err).To(MatchError(token.TokenRevokedError))
+		_, err = tokenManager.Parse(pair.AccessToken)
+		Expect(err).To(MatchError(token.TokenRevokedError))
+	})
+
+	When("Refresh token is reused", func() {
+		var newPair *token.TokenPair
+
+		BeforeEach(func() {
+			var err error
+			newPair, err = refreshManager.Refresh(ctx, pair.RefreshToken)
+			Expect(err).To(BeNil())
+			_, err = refreshManager.Refresh(ctx, pair.RefreshToken)
+			Expect(err).To(MatchError(token.RefreshTokenReusedError))
+		})
+
+		It("revokes the family", func() {
+			_, err := refreshManager.Refresh(ctx, newPair.RefreshToken)
+			Expect(err).To(MatchError(token.TokenRevokedError))
+			_, err = tokenManager.Parse(newPair.AccessToken)
+			Expect(err).To(MatchError(token.TokenRevokedError))
+			_, err = tokenManager.Parse(pair.AccessToken)
+			Expect(err).To(MatchError(token.TokenRevokedError))
+		})
+
+		It("does not revoke other families", func() {
+			otherPair, err := refreshManager.Generate(payload)
+			Expect(err).To(BeNil())
+			_, err = refreshManager.Refresh(ctx, otherPair.RefreshToken)
+			Expect(err).To(BeNil())
+		})
+	})
+})
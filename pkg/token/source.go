@@ -0,0 +1,139 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// SourceType is where a token is read from in a request.
+type SourceType string
+
+const (
+	// AuthorizationSource reads the token of the Authorization: Bearer header.
+	AuthorizationSource SourceType = "authorization"
+	// HeaderSource reads the token of a custom header, with or without the Bearer scheme.
+	HeaderSource SourceType = "header"
+	// CookieSource reads the token of a cookie.
+	CookieSource SourceType = "cookie"
+	// QuerySource reads the token of a query parameter.
+	QuerySource SourceType = "query"
+)
+
+var (
+	UnknownSourceError = errors.New("unknown token source")
+	TokenFormatError   = errors.New("token format is invalid")
+)
+
+var (
+	bearerTokenRegex = regexp.MustCompile(`Bearer (.+\..+\..+)`)
+	tokenRegex       = regexp.MustCompile(`^[^.\s]+\.[^.\s]+\.[^.\s]+$`)
+)
+
+// Source is a place of the request to look for the token. Name is the name of the header, the cookie
+// or the query parameter, and is not used by AuthorizationSource.
+type Source struct {
+	Type SourceType
+	Name string
+}
+
+// ParseExtractor returns the extractor of the comma separated sources,
+// e.g. authorization,cookie:session,query:access_token,header:X-Auth-Token.
+func ParseExtractor(spec string) (*Extractor, error) {
+	var sources []Source
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		if len(field) == 0 {
+			continue
+		}
+		sourceType, name := field, ""
+		if i := strings.IndexByte(field, ':'); i >= 0 {
+			sourceType, name = field[:i], strings.TrimSpace(field[i+1:])
+		}
+		sources = append(sources, Source{Type: SourceType(sourceType), Name: name})
+	}
+	return NewExtractor(sources...)
+}
+
+// Extractor looks for the token in its sources in order. A nil Extractor only reads the Authorization header.
+type Extractor struct {
+	sources []Source
+}
+
+// NewExtractor returns the extractor of the sources, which must have a name unless they are AuthorizationSource.
+// The extractor reads the Authorization header when there is no source.
+func NewExtractor(sources ...Source) (*Extractor, error) {
+	if len(sources) == 0 {
+		sources = []Source{{Type: AuthorizationSource}}
+	}
+	for _, source := range sources {
+		switch source.Type {
+		case AuthorizationSource:
+		case HeaderSource, CookieSource, QuerySource:
+			if len(source.Name) == 0 {
+				return nil, fmt.Errorf("%w: %s without name", UnknownSourceError, source.Type)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", UnknownSourceError, source.Type)
+		}
+	}
+	return &Extractor{sources: sources}, nil
+}
+
+// Extract returns the token of the first source that is present in the request, skipping the Authorization header
+// of the other schemes, e.g. Basic of the upstream. It returns TokenFormatError if there is none, or if the value of the source is not a token.
+func (e *Extractor) Extract(header http.Header, query url.Values) (string, error) {
+	sources := []Source{{Type: AuthorizationSource}}
+	if e != nil {
+		sources = e.sources
+	}
+	for _, source := range sources {
+		value, ok := lookupSource(source, header, query)
+		if !ok {
+			continue
+		}
+		if source.Type == AuthorizationSource {
+			if !bearerTokenRegex.MatchString(value) {
+				continue
+			}
+			return bearerTokenRegex.FindStringSubmatch(value)[1], nil
+		}
+		if source.Type == HeaderSource && len(value) > 7 && strings.EqualFold(value[:7], "Bearer ") {
+			value = value[7:]
+		}
+		if !tokenRegex.MatchString(value) {
+			return "", TokenFormatError
+		}
+		return value, nil
+	}
+	return "", TokenFormatError
+}
+
+// ExtractRequest returns the token of the HTTP request.
+func (e *Extractor) ExtractRequest(r *http.Request) (string, error) {
+	return e.Extract(r.Header, r.URL.Query())
+}
+
+func lookupSource(source Source, header http.Header, query url.Values) (string, bool) {
+	switch source.Type {
+	case AuthorizationSource:
+		value := header.Get("Authorization")
+		return value, len(value) > 0
+	case HeaderSource:
+		value := header.Get(source.Name)
+		return value, len(value) > 0
+	case CookieSource:
+		cookie, err := (&http.Request{Header: header}).Cookie(source.Name)
+		if err != nil || len(cookie.Value) == 0 {
+			return "", false
+		}
+		return cookie.Value, true
+	case QuerySource:
+		value := query.Get(source.Name)
+		return value, len(value) > 0
+	}
+	return "", false
+}
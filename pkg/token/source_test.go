@@ -0,0 +1,127 @@
+package token_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"net/http"
+	"net/url"
+)
+
+var _ = Describe("Extractor", func() {
+	var (
+		extractor *token.Extractor
+		header    http.Header
+		query     url.Values
+	)
+
+	BeforeEach(func() {
+		var err error
+		extractor, err = token.ParseExtractor("authorization, cookie:session, query:access_token, header:X-Auth-Token")
+		Expect(err).To(BeNil())
+		header = http.Header{}
+		query = url.Values{}
+	})
+
+	for _, c := range []struct {
+		description string
+		setup       func(header http.Header, query url.Values)
+		token       string
+	}{
+		{
+			description: "reads the Authorization header",
+			setup: func(header http.Header, query url.Values) {
+				header.Set("Authorization", "Bearer a.b.c")
+				header.Set("Cookie", "session=d.e.f")
+			},
+			token: "a.b.c",
+		},
+		{
+			description: "reads the cookie",
+			setup: func(header http.Header, query url.Values) {
+				header.Set("Cookie", "theme=dark; session=d.e.f")
+				query.Set("access_token", "g.h.i")
+			},
+			token: "d.e.f",
+		},
+		{
+			description: "reads the query parameter",
+			setup: func(header http.Header, query url.Values) {
+				query.Set("access_token", "g.h.i")
+				header.Set("X-Auth-Token", "j.k.l")
+			},
+			token: "g.h.i",
+		},
+		{
+			description: "skips the Authorization header of other schemes",
+			setup: func(header http.Header, query url.Values) {
+				header.Set("Authorization", "Basic dXNlcjpwYXNz")
+				header.Set("Cookie", "session=d.e.f")
+			},
+			token: "d.e.f",
+		},
+		{
+			description: "reads the custom header with or without the Bearer scheme",
+			setup: func(header http.Header, query url.Values) {
+				header.Set("X-Auth-Token", "bearer j.k.l")
+			},
+			token: "j.k.l",
+		},
+	} {
+		c := c
+		It(c.description, func() {
+			c.setup(header, query)
+			tokenString, err := extractor.Extract(header, query)
+			Expect(err).To(BeNil())
+			Expect(tokenString).To(Equal(c.token))
+		})
+	}
+
+	It("returns TokenFormatError if the first present source is not a token", func() {
+		header.Set("Cookie", "session=not-a-token")
+		query.Set("access_token", "g.h.i")
+		_, err := extractor.Extract(header, query)
+		Expect(err).To(MatchError(token.TokenFormatError))
+	})
+
+	It("returns TokenFormatError if the Authorization header of another scheme is the only source", func() {
+		header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		_, err := extractor.Extract(header, query)
+		Expect(err).To(MatchError(token.TokenFormatError))
+	})
+
+	It("returns TokenFormatError without a token", func() {
+		_, err := extractor.Extract(header, query)
+		Expect(err).To(MatchError(token.TokenFormatError))
+	})
+
+	It("only reads the enabled sources", func() {
+		extractor, err := token.ParseExtractor("cookie:session")
+		Expect(err).To(BeNil())
+		header.Set("Authorization", "Bearer a.b.c")
+		_, err = extractor.Extract(header, query)
+		Expect(err).To(MatchError(token.TokenFormatError))
+	})
+
+	It("reads the Authorization header without sources", func() {
+		for _, extractor := range []*token.Extractor{nil, mustParseExtractor("")} {
+			header.Set("Authorization", "Bearer a.b.c")
+			tokenString, err := extractor.ExtractRequest(&http.Request{Header: header, URL: &url.URL{}})
+			Expect(err).To(BeNil())
+			Expect(tokenString).To(Equal("a.b.c"))
+		}
+	})
+
+	It("returns UnknownSourceError for invalid sources", func() {
+		for _, spec := range []string{"body:token", "cookie", "query:", "header"} {
+			_, err := token.ParseExtractor(spec)
+			Expect(err).To(MatchError(token.UnknownSourceError), spec)
+		}
+	})
+})
+
+func mustParseExtractor(spec string) *token.Extractor {
+	extractor, err := token.ParseExtractor(spec)
+	Expect(err).To(BeNil())
+	return extractor
+}
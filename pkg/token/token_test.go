@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/signature"
 	"github.com/thetkpark/heimdall/pkg/token"
 	"github.com/thetkpark/heimdall/test/mock_encryption"
 	"github.com/thetkpark/heimdall/test/mock_signature"
@@ -27,14 +28,20 @@ var _ = Describe("Token Manager", func() {
 		mockEncryption = mock_encryption.NewMockManager(mockCtrl)
 		mockSignature = mock_signature.NewMockManager(mockCtrl)
 		payload = config.Payload{
-			CustomPayload: config.CustomPayload{UserID: 99},
+			CustomPayload: config.CustomPayload{"user_id": json.Number("99")},
 			MetadataPayload: config.MetadataPayload{
-				IssuedAt:  time.Now().UTC(),
-				ExpiredAt: time.Now().Add(time.Second * 10).UTC(),
+				IssuedAt:  config.NewNumericDate(time.Now()),
+				ExpiredAt: config.NewNumericDate(time.Now().Add(time.Second * 10)),
+				ID:        "token-id",
 			},
 		}
 		var err error
-		rawPayload, err = json.Marshal(payload)
+		rawPayload, err = json.Marshal(map[string]interface{}{
+			"user_id":    payload.CustomPayload["user_id"],
+			"issued_at":  payload.IssuedAt.Time,
+			"expired_at": payload.ExpiredAt.Time,
+			"jti":        payload.ID,
+		})
 		Expect(err).To(BeNil())
 	})
 
@@ -90,4 +97,158 @@ var _ = Describe("Token Manager", func() {
 			Expect(*retrievedPayload).To(Equal(payload))
 		})
 	})
+
+	Context("Token format", func() {
+		var (
+			jws           signature.Manager
+			legacyManager token.Manager
+			newManager    func(format token.Format) token.Manager
+		)
+
+		BeforeEach(func() {
+			jws = signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4")
+			legacyManager = token.NewTokenManager(jws, nil)
+			newManager = func(format token.Format) token.Manager {
+				mng := token.NewTokenManager(jws, nil)
+				mng.SetFormat(format)
+				mng.SetIssuer("https://heimdall.example.com")
+				mng.SetAudience("api")
+				return mng
+			}
+		})
+
+		It("rejects unknown format", func() {
+			_, err := token.ParseFormat("jwt")
+			Expect(err).To(Equal(token.UnknownFormatError))
+		})
+
+		When("Standard format", func() {
+			BeforeEach(func() {
+				tokenManager = newManager(token.StandardFormat)
+			})
+
+			It("emits the registered claims as NumericDate", func() {
+				tokenString, err := tokenManager.Generate(payload)
+				Expect(err).To(BeNil())
+				rawClaims, err := jws.Verify([]byte(tokenString))
+				Expect(err).To(BeNil())
+
+				var claims map[string]interface{}
+				Expect(json.Unmarshal(rawClaims, &claims)).To(Succeed())
+				Expect(claims).To(HaveKeyWithValue("iss", "https://heimdall.example.com"))
+				Expect(claims).To(HaveKeyWithValue("sub", "99"))
+				Expect(claims).To(HaveKeyWithValue("aud", "api"))
+				Expect(claims).To(HaveKeyWithValue("exp", float64(payload.ExpiredAt.Unix())))
+				Expect(claims).To(HaveKeyWithValue("iat", float64(payload.IssuedAt.Unix())))
+				Expect(claims).To(HaveKeyWithValue("nbf", float64(payload.IssuedAt.Unix())))
+				Expect(claims).To(HaveKey("jti"))
+				Expect(claims).ToNot(HaveKey("expired_at"))
+			})
+
+			It("can parse the token", func() {
+				tokenString, err := tokenManager.Generate(payload)
+				Expect(err).To(BeNil())
+				retrievedPayload, err := tokenManager.Parse(tokenString)
+				Expect(err).To(BeNil())
+				Expect(retrievedPayload.CustomPayload).To(Equal(payload.CustomPayload))
+				Expect(retrievedPayload.ExpiredAt).To(Equal(payload.ExpiredAt))
+				Expect(retrievedPayload.Audience).To(Equal(config.Audience{"api"}))
+				Expect(retrievedPayload.ID).ToNot(BeEmpty())
+			})
+
+			It("rejects expired token", func() {
+				payload.ExpiredAt = config.NewNumericDate(time.Now().Add(-time.Minute))
+				tokenString, err := tokenManager.Generate(payload)
+				Expect(err).To(BeNil())
+				_, err = tokenManager.Parse(tokenString)
+				Expect(err).To(MatchError(token.TokenExpiredError))
+			})
+
+			It("rejects token that is not valid yet", func() {
+				payload.NotBefore = config.NewNumericDate(time.Now().Add(time.Hour))
+				tokenString, err := tokenManager.Generate(payload)
+				Expect(err).To(BeNil())
+				_, err = tokenManager.Parse(tokenString)
+				Expect(err).To(MatchError(token.TokenNotValidYetError))
+			})
+
+			It("rejects legacy token", func() {
+				tokenString, err := legacyManager.Generate(payload)
+				Expect(err).To(BeNil())
+				_, err = tokenManager.Parse(tokenString)
+				Expect(err).To(Equal(token.LegacyClaimsError))
+			})
+		})
+
+		When("Legacy format", func() {
+			var unboundedToken string
+
+			BeforeEach(func() {
+				tokenManager = legacyManager
+				// Tokens generated without TOKEN_VALID_TIME before expiry was enforced
+				issuedAt := time.Now().Add(-24 * time.Hour).UTC()
+				rawClaims, err := json.Marshal(map[string]interface{}{
+					"user_id":    99,
+					"issued_at":  issuedAt,
+					"expired_at": issuedAt.Add(time.Microsecond),
+				})
+				Expect(err).To(BeNil())
+				signedToken, err := jws.Sign(rawClaims)
+				Expect(err).To(BeNil())
+				unboundedToken = string(signedToken)
+			})
+
+			It("rejects expired legacy token", func() {
+				payload.ExpiredAt = config.NewNumericDate(time.Now().Add(-time.Minute))
+				tokenString, err := legacyManager.Generate(payload)
+				Expect(err).To(BeNil())
+				_, err = tokenManager.Parse(tokenString)
+				Expect(err).To(MatchError(token.TokenExpiredError))
+			})
+
+			It("accepts legacy token whose expired_at is issued_at as never expiring", func() {
+				retrievedPayload, err := tokenManager.Parse(unboundedToken)
+				Expect(err).To(BeNil())
+				Expect(retrievedPayload.ExpiredAt).To(BeNil())
+				Expect(retrievedPayload.IssuedAt).ToNot(BeNil())
+			})
+
+			It("accepts the same token in compat format", func() {
+				retrievedPayload, err := newManager(token.CompatFormat).Parse(unboundedToken)
+				Expect(err).To(BeNil())
+				Expect(retrievedPayload.ExpiredAt).To(BeNil())
+			})
+		})
+
+		When("Compat format", func() {
+			BeforeEach(func() {
+				tokenManager = newManager(token.CompatFormat)
+			})
+
+			It("accepts legacy token", func() {
+				tokenString, err := legacyManager.Generate(payload)
+				Expect(err).To(BeNil())
+				retrievedPayload, err := tokenManager.Parse(tokenString)
+				Expect(err).To(BeNil())
+				Expect(retrievedPayload.CustomPayload).To(Equal(payload.CustomPayload))
+				Expect(retrievedPayload.ExpiredAt.Unix()).To(Equal(payload.ExpiredAt.Unix()))
+			})
+
+			It("rejects expired legacy token", func() {
+				payload.ExpiredAt = config.NewNumericDate(time.Now().Add(-time.Minute))
+				tokenString, err := legacyManager.Generate(payload)
+				Expect(err).To(BeNil())
+				_, err = tokenManager.Parse(tokenString)
+				Expect(err).To(MatchError(token.TokenExpiredError))
+			})
+
+			It("accepts standard token", func() {
+				tokenString, err := tokenManager.Generate(payload)
+				Expect(err).To(BeNil())
+				retrievedPayload, err := tokenManager.Parse(tokenString)
+				Expect(err).To(BeNil())
+				Expect(retrievedPayload.Issuer).To(Equal("https://heimdall.example.com"))
+			})
+		})
+	})
 })
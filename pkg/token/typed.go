@@ -0,0 +1,235 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/encryption"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"io"
+	"time"
+)
+
+// TypedManager generates and parses tokens whose claims are T.
+// T is converted through its JSON encoding, so the registered claims are read from and written to
+// its "iss", "sub", "aud", "exp", "nbf", "iat" and "jti" fields, e.g. by embedding config.MetadataPayload.
+type TypedManager[T any] interface {
+	Generate(claims T) (string, error)
+	// GenerateBatch generates the token of each claims, signing them all with the same key.
+	// The error of each claims is at the same index, and its token is empty.
+	GenerateBatch(claims []T) ([]string, []error)
+	Parse(token string) (*T, error)
+}
+
+func NewTypedManager[T any](sig signature.Manager, enc encryption.Manager, opts ...Option) *typedManager[T] {
+	mng := &typedManager[T]{
+		encryptionManager: enc,
+		signatureManager:  sig,
+		options:           options{format: LegacyFormat, now: time.Now},
+	}
+	for _, opt := range opts {
+		opt(&mng.options)
+	}
+	return mng
+}
+
+type typedManager[T any] struct {
+	signatureManager  signature.Manager
+	encryptionManager encryption.Manager
+	options
+}
+
+// options is the part of the manager that does not depend on the claims type.
+type options struct {
+	format          Format
+	issuer          string
+	audience        config.Audience
+	leeway          time.Duration
+	now             func() time.Time
+	validators      []Validator
+	claimsSchema    *ClaimsSchema
+	revocationStore revocation.Store
+}
+
+func (m *typedManager[T]) SetEncryptionManager(enc encryption.Manager) {
+	m.encryptionManager = enc
+}
+
+func (m *typedManager[T]) SetFormat(format Format) {
+	m.format = format
+}
+
+// SetIssuer sets the "iss" claim of the generated tokens that have none.
+func (m *typedManager[T]) SetIssuer(issuer string) {
+	m.issuer = issuer
+}
+
+// SetAudience sets the "aud" claim of the generated tokens that have none.
+func (m *typedManager[T]) SetAudience(audience ...string) {
+	m.audience = audience
+}
+
+func (m typedManager[T]) Generate(claims T) (string, error) {
+	payload, err := toPayload(claims)
+	if err != nil {
+		return "", err
+	}
+	if err := m.validateClaims(payload.CustomPayload); err != nil {
+		return "", err
+	}
+	return m.generate(m.signatureManager, payload)
+}
+
+func (m typedManager[T]) GenerateBatch(claims []T) ([]string, []error) {
+	signer := m.signer()
+	tokens := make([]string, len(claims))
+	errs := make([]error, len(claims))
+	for i, c := range claims {
+		payload, err := toPayload(c)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if err := m.validateClaims(payload.CustomPayload); err != nil {
+			errs[i] = err
+			continue
+		}
+		tokens[i], errs[i] = m.generate(signer, payload)
+	}
+	return tokens, errs
+}
+
+// signer returns the signer of the current signing key, which is looked up once for a batch.
+func (m typedManager[T]) signer() signature.Signer {
+	if provider, ok := m.signatureManager.(signature.SignerProvider); ok {
+		return provider.Signer()
+	}
+	return m.signatureManager
+}
+
+// generate signs the payload whose custom claims are already validated.
+func (m typedManager[T]) generate(signer signature.Signer, payload config.Payload) (string, error) {
+	if err := m.setRegisteredClaims(&payload); err != nil {
+		return "", err
+	}
+
+	rawPayload, err := m.format.encodeClaims(payload)
+	if err != nil {
+		return "", err
+	}
+
+	if m.encryptionManager != nil {
+		rawPayload, err = m.encryptionManager.Encrypt(rawPayload)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	token, err := signer.Sign(rawPayload)
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
+
+// Parse rejects the refresh tokens, which are only accepted by RefreshManager.Refresh.
+func (m typedManager[T]) Parse(token string) (*T, error) {
+	payload, err := m.parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if isRefreshToken(payload) {
+		return nil, &ValidationError{Err: TokenUseError}
+	}
+	return fromPayload[T](payload)
+}
+
+func (m typedManager[T]) parse(token string) (*config.Payload, error) {
+	rawPayload, err := m.signatureManager.Verify([]byte(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if m.encryptionManager != nil {
+		rawPayload, err = m.encryptionManager.Decrypt(rawPayload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	payload, err := m.format.decodeClaims(rawPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.validate(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// toPayload converts the claims into config.Payload, on which the claims are validated and encoded.
+func toPayload[T any](claims T) (config.Payload, error) {
+	if payload, ok := any(claims).(config.Payload); ok {
+		return payload, nil
+	}
+	rawClaims, err := json.Marshal(claims)
+	if err != nil {
+		return config.Payload{}, err
+	}
+	var payload config.Payload
+	if err := json.Unmarshal(rawClaims, &payload); err != nil {
+		return config.Payload{}, err
+	}
+	return payload, nil
+}
+
+func fromPayload[T any](payload *config.Payload) (*T, error) {
+	if claims, ok := any(payload).(*T); ok {
+		return claims, nil
+	}
+	rawClaims, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var claims T
+	if err := json.Unmarshal(rawClaims, &claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// setRegisteredClaims fills the registered claims that the payload does not set.
+func (o options) setRegisteredClaims(payload *config.Payload) error {
+	if len(payload.Issuer) == 0 {
+		payload.Issuer = o.issuer
+	}
+	if len(payload.Audience) == 0 {
+		payload.Audience = o.audience
+	}
+	payload.Subject = Subject(payload)
+	if payload.IssuedAt == nil {
+		payload.IssuedAt = config.NewNumericDate(o.now())
+	}
+	if payload.NotBefore == nil {
+		payload.NotBefore = payload.IssuedAt
+	}
+	if len(payload.ID) == 0 {
+		id, err := newTokenID()
+		if err != nil {
+			return err
+		}
+		payload.ID = id
+	}
+	return nil
+}
+
+func newTokenID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, id); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(id), nil
+}
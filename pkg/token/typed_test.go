@@ -0,0 +1,118 @@
+package token_test
+
+import (
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"time"
+)
+
+type accessClaims struct {
+	UserID uint64   `json:"user_id"`
+	Roles  []string `json:"roles,omitempty"`
+	config.MetadataPayload
+}
+
+var _ = Describe("Typed Manager", func() {
+	var (
+		jws          signature.Manager
+		opts         []token.Option
+		tokenManager token.TypedManager[accessClaims]
+		claims       accessClaims
+	)
+
+	BeforeEach(func() {
+		jws = signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4")
+		opts = nil
+		claims = accessClaims{
+			UserID: 99,
+			Roles:  []string{"admin"},
+			MetadataPayload: config.MetadataPayload{
+				ExpiredAt: config.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		mng := token.NewTypedManager[accessClaims](jws, nil, opts...)
+		mng.SetFormat(token.StandardFormat)
+		mng.SetIssuer("https://heimdall.example.com")
+		tokenManager = mng
+	})
+
+	It("round trips the claims", func() {
+		tokenString, err := tokenManager.Generate(claims)
+		Expect(err).To(BeNil())
+		retrievedClaims, err := tokenManager.Parse(tokenString)
+		Expect(err).To(BeNil())
+		Expect(retrievedClaims.UserID).To(Equal(claims.UserID))
+		Expect(retrievedClaims.Roles).To(Equal(claims.Roles))
+		Expect(retrievedClaims.ExpiredAt).To(Equal(claims.ExpiredAt))
+		Expect(retrievedClaims.Issuer).To(Equal("https://heimdall.example.com"))
+		Expect(retrievedClaims.Subject).To(Equal("99"))
+		Expect(retrievedClaims.ID).ToNot(BeEmpty())
+	})
+
+	It("is interoperable with the server Manager", func() {
+		tokenString, err := tokenManager.Generate(claims)
+		Expect(err).To(BeNil())
+		payloadManager := token.NewTokenManager(jws, nil)
+		payloadManager.SetFormat(token.StandardFormat)
+		payload, err := payloadManager.Parse(tokenString)
+		Expect(err).To(BeNil())
+		Expect(payload.CustomPayload).To(Equal(config.CustomPayload{
+			"user_id": json.Number("99"),
+			"roles":   []interface{}{"admin"},
+		}))
+	})
+
+	When("Token is expired", func() {
+		BeforeEach(func() {
+			claims.ExpiredAt = config.NewNumericDate(time.Now().Add(-time.Minute))
+		})
+
+		It("should return TokenExpiredError", func() {
+			tokenString, err := tokenManager.Generate(claims)
+			Expect(err).To(BeNil())
+			_, err = tokenManager.Parse(tokenString)
+			Expect(err).To(MatchError(token.TokenExpiredError))
+		})
+	})
+
+	When("Claims do not conform to the schema", func() {
+		BeforeEach(func() {
+			schema, err := token.NewClaimsSchema([]byte(`{"required": ["tenant"]}`))
+			Expect(err).To(BeNil())
+			opts = append(opts, token.WithClaimsSchema(schema))
+		})
+
+		It("should return ClaimsError", func() {
+			_, err := tokenManager.Generate(claims)
+			var claimsErr *token.ClaimsError
+			Expect(errors.As(err, &claimsErr)).To(BeTrue())
+		})
+	})
+
+	When("Custom validator rejects the token", func() {
+		BeforeEach(func() {
+			opts = append(opts, token.WithValidator(func(payload *config.Payload, _ time.Time) error {
+				if _, ok := payload.CustomPayload["roles"]; ok {
+					return errors.New("roles are not allowed")
+				}
+				return nil
+			}))
+		})
+
+		It("should return ValidationError", func() {
+			tokenString, err := tokenManager.Generate(claims)
+			Expect(err).To(BeNil())
+			_, err = tokenManager.Parse(tokenString)
+			var validationErr *token.ValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,148 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"time"
+)
+
+var (
+	TokenExpiredError       = errors.New("token is expired")
+	TokenNotValidYetError   = errors.New("token is not valid yet")
+	TokenMissingExpiryError = errors.New("token has no expiry")
+	TokenIssuerError        = errors.New("token issuer is not accepted")
+	TokenAudienceError      = errors.New("token audience is not accepted")
+	TokenRevokedError       = errors.New("token is revoked")
+)
+
+// ValidationError is returned by Parse when the token is authentic but rejected by a Validator.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validator checks the claims of a parsed token at the given time.
+// It is passed the claims as config.Payload regardless of the claims type of the TypedManager.
+type Validator func(payload *config.Payload, now time.Time) error
+
+// Option configures the validation of the manager.
+type Option func(o *options)
+
+// WithLeeway allows the clock of the issuer and the verifier to be apart by the given duration.
+func WithLeeway(leeway time.Duration) Option {
+	return func(o *options) {
+		o.leeway = leeway
+	}
+}
+
+// WithClock replaces time.Now as the current time of the validation.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// WithRequiredExpiry rejects tokens without expiry.
+func WithRequiredExpiry() Option {
+	return WithValidator(func(payload *config.Payload, _ time.Time) error {
+		if payload.ExpiredAt == nil {
+			return TokenMissingExpiryError
+		}
+		return nil
+	})
+}
+
+// WithExpectedIssuer rejects tokens whose "iss" claim is not the issuer.
+func WithExpectedIssuer(issuer string) Option {
+	return WithValidator(func(payload *config.Payload, _ time.Time) error {
+		if payload.Issuer != issuer {
+			return TokenIssuerError
+		}
+		return nil
+	})
+}
+
+// WithExpectedAudience rejects tokens whose "aud" claim does not contain any of the audience.
+func WithExpectedAudience(audience ...string) Option {
+	return WithValidator(func(payload *config.Payload, _ time.Time) error {
+		for _, aud := range audience {
+			if payload.Audience.Contains(aud) {
+				return nil
+			}
+		}
+		return TokenAudienceError
+	})
+}
+
+// WithRevocationStore rejects tokens revoked in the store.
+func WithRevocationStore(store revocation.Store) Option {
+	return func(o *options) {
+		o.revocationStore = store
+	}
+}
+
+// Subject returns the "sub" claim of the payload, or the user_id claim of the legacy tokens that have no "sub" claim.
+func Subject(payload *config.Payload) string {
+	if len(payload.Subject) > 0 {
+		return payload.Subject
+	}
+	if userID, ok := payload.CustomPayload[UserIDClaim]; ok {
+		return fmt.Sprint(userID)
+	}
+	return ""
+}
+
+// WithValidator appends a custom validator, run after the built-in expiry and not-before validators.
+func WithValidator(validator Validator) Option {
+	return func(o *options) {
+		o.validators = append(o.validators, validator)
+	}
+}
+
+func (o options) validate(payload *config.Payload) error {
+	now := o.now()
+	if payload.ExpiredAt != nil && !now.Before(payload.ExpiredAt.Add(o.leeway)) {
+		return &ValidationError{Err: TokenExpiredError}
+	}
+	if payload.NotBefore != nil && now.Add(o.leeway).Before(payload.NotBefore.Time) {
+		return &ValidationError{Err: TokenNotValidYetError}
+	}
+	if o.revocationStore != nil {
+		var issuedAt time.Time
+		if payload.IssuedAt != nil {
+			issuedAt = payload.IssuedAt.Time
+		}
+		revoked, err := o.revocationStore.IsRevoked(context.Background(), payload.ID, Subject(payload), issuedAt)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return &ValidationError{Err: TokenRevokedError}
+		}
+		if family, ok := payload.CustomPayload[FamilyClaim].(string); ok {
+			revoked, err = o.revocationStore.IsRevoked(context.Background(), family, "", time.Time{})
+			if err != nil {
+				return err
+			}
+			if revoked {
+				return &ValidationError{Err: TokenRevokedError}
+			}
+		}
+	}
+	for _, validator := range o.validators {
+		if err := validator(payload, now); err != nil {
+			return &ValidationError{Err: err}
+		}
+	}
+	return nil
+}
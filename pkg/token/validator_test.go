@@ -0,0 +1,189 @@
+package token_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/thetkpark/heimdall/pkg/config"
+	"github.com/thetkpark/heimdall/pkg/revocation"
+	"github.com/thetkpark/heimdall/pkg/signature"
+	"github.com/thetkpark/heimdall/pkg/token"
+	"time"
+)
+
+var _ = Describe("Token validation", func() {
+	var (
+		jws        signature.Manager
+		now        time.Time
+		payload    config.Payload
+		opts       []token.Option
+		format     token.Format
+		parseErr   error
+		parsed     *config.Payload
+		expectFail = func(reason error) {
+			var validationErr *token.ValidationError
+			Expect(errors.As(parseErr, &validationErr)).To(BeTrue())
+			Expect(validationErr.Err).To(Equal(reason))
+			Expect(errors.Is(parseErr, reason)).To(BeTrue())
+			Expect(parsed).To(BeNil())
+		}
+	)
+
+	BeforeEach(func() {
+		jws = signature.NewJWS("E2sK$Cps7v1sB2RW010HlSWdpS&CSOy4")
+		now = time.Now()
+		format = token.StandardFormat
+		opts = []token.Option{token.WithClock(func() time.Time { return now })}
+		payload = config.Payload{
+			CustomPayload: config.CustomPayload{"user_id": json.Number("99")},
+			MetadataPayload: config.MetadataPayload{
+				Issuer:    "https://heimdall.example.com",
+				Audience:  config.Audience{"api"},
+				IssuedAt:  config.NewNumericDate(now),
+				ExpiredAt: config.NewNumericDate(now.Add(time.Minute)),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		tokenManager := token.NewTokenManager(jws, nil, opts...)
+		tokenManager.SetFormat(format)
+		tokenString, err := tokenManager.Generate(payload)
+		Expect(err).To(BeNil())
+		parsed, parseErr = tokenManager.Parse(tokenString)
+	})
+
+	When("Token is valid", func() {
+		It("should return the payload", func() {
+			Expect(parseErr).To(BeNil())
+			Expect(parsed.CustomPayload).To(Equal(payload.CustomPayload))
+		})
+	})
+
+	When("Token is expired", func() {
+		BeforeEach(func() {
+			payload.ExpiredAt = config.NewNumericDate(now.Add(-time.Second))
+		})
+
+		It("should return TokenExpiredError", func() {
+			expectFail(token.TokenExpiredError)
+		})
+	})
+
+	When("Legacy token is expired", func() {
+		BeforeEach(func() {
+			format = token.LegacyFormat
+			payload.ExpiredAt = config.NewNumericDate(now.Add(-time.Second))
+		})
+
+		It("should return TokenExpiredError", func() {
+			expectFail(token.TokenExpiredError)
+		})
+	})
+
+	When("Token is expired within the leeway", func() {
+		BeforeEach(func() {
+			payload.ExpiredAt = config.NewNumericDate(now.Add(-time.Second))
+			opts = append(opts, token.WithLeeway(time.Minute))
+		})
+
+		It("should return the payload", func() {
+			Expect(parseErr).To(BeNil())
+		})
+	})
+
+	When("Token is not valid yet", func() {
+		BeforeEach(func() {
+			payload.NotBefore = config.NewNumericDate(now.Add(time.Hour))
+		})
+
+		It("should return TokenNotValidYetError", func() {
+			expectFail(token.TokenNotValidYetError)
+		})
+	})
+
+	When("Token has no expiry but expiry is required", func() {
+		BeforeEach(func() {
+			payload.ExpiredAt = nil
+			opts = append(opts, token.WithRequiredExpiry())
+		})
+
+		It("should return TokenMissingExpiryError", func() {
+			expectFail(token.TokenMissingExpiryError)
+		})
+	})
+
+	When("Token issuer is not expected", func() {
+		BeforeEach(func() {
+			opts = append(opts, token.WithExpectedIssuer("https://other.example.com"))
+		})
+
+		It("should return TokenIssuerError", func() {
+			expectFail(token.TokenIssuerError)
+		})
+	})
+
+	When("Token audience is expected", func() {
+		BeforeEach(func() {
+			opts = append(opts, token.WithExpectedAudience("web", "api"))
+		})
+
+		It("should return the payload", func() {
+			Expect(parseErr).To(BeNil())
+		})
+	})
+
+	When("Token audience is not expected", func() {
+		BeforeEach(func() {
+			opts = append(opts, token.WithExpectedAudience("web"))
+		})
+
+		It("should return TokenAudienceError", func() {
+			expectFail(token.TokenAudienceError)
+		})
+	})
+
+	When("Token is revoked", func() {
+		BeforeEach(func() {
+			store := revocation.NewMemoryStore()
+			Expect(store.RevokeSubject(context.Background(), "99", now.Add(time.Second))).To(Succeed())
+			opts = append(opts, token.WithRevocationStore(store))
+		})
+
+		It("should return TokenRevokedError", func() {
+			expectFail(token.TokenRevokedError)
+		})
+	})
+
+	When("Legacy token is revoked", func() {
+		BeforeEach(func() {
+			format = token.LegacyFormat
+			store := revocation.NewMemoryStore()
+			Expect(store.RevokeSubject(context.Background(), "99", now.Add(time.Second))).To(Succeed())
+			opts = append(opts, token.WithRevocationStore(store))
+		})
+
+		It("should return TokenRevokedError", func() {
+			expectFail(token.TokenRevokedError)
+		})
+	})
+
+	When("Custom validator rejects the token", func() {
+		var reason = errors.New("user is banned")
+
+		BeforeEach(func() {
+			opts = append(opts, token.WithValidator(func(payload *config.Payload, _ time.Time) error {
+				if payload.CustomPayload["user_id"] == json.Number("99") {
+					return reason
+				}
+				return nil
+			}))
+		})
+
+		It("should return the validator error", func() {
+			expectFail(reason)
+		})
+	})
+})
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/token/exchange.go
+
+// Package mock_token is a generated GoMock package.
+package mock_token
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	config "github.com/thetkpark/heimdall/pkg/config"
+)
+
+// MockExchangeManager is a mock of ExchangeManager interface.
+type MockExchangeManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeManagerMockRecorder
+}
+
+// MockExchangeManagerMockRecorder is the mock recorder for MockExchangeManager.
+type MockExchangeManagerMockRecorder struct {
+	mock *MockExchangeManager
+}
+
+// NewMockExchangeManager creates a new mock instance.
+func NewMockExchangeManager(ctrl *gomock.Controller) *MockExchangeManager {
+	mock := &MockExchangeManager{ctrl: ctrl}
+	mock.recorder = &MockExchangeManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeManager) EXPECT() *MockExchangeManagerMockRecorder {
+	return m.recorder
+}
+
+// Delegate mocks base method.
+func (m *MockExchangeManager) Delegate(subject *config.Payload, actor string, payload config.Payload) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delegate", subject, actor, payload)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delegate indicates an expected call of Delegate.
+func (mr *MockExchangeManagerMockRecorder) Delegate(subject, actor, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delegate", reflect.TypeOf((*MockExchangeManager)(nil).Delegate), subject, actor, payload)
+}
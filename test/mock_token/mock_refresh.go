@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/token/refresh.go
+
+// Package mock_token is a generated GoMock package.
+package mock_token
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	config "github.com/thetkpark/heimdall/pkg/config"
+	token "github.com/thetkpark/heimdall/pkg/token"
+)
+
+// MockRefreshManager is a mock of RefreshManager interface.
+type MockRefreshManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshManagerMockRecorder
+}
+
+// MockRefreshManagerMockRecorder is the mock recorder for MockRefreshManager.
+type MockRefreshManagerMockRecorder struct {
+	mock *MockRefreshManager
+}
+
+// NewMockRefreshManager creates a new mock instance.
+func NewMockRefreshManager(ctrl *gomock.Controller) *MockRefreshManager {
+	mock := &MockRefreshManager{ctrl: ctrl}
+	mock.recorder = &MockRefreshManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshManager) EXPECT() *MockRefreshManagerMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockRefreshManager) Generate(payload config.Payload) (*token.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", payload)
+	ret0, _ := ret[0].(*token.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockRefreshManagerMockRecorder) Generate(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockRefreshManager)(nil).Generate), payload)
+}
+
+// GenerateBatch mocks base method.
+func (m *MockRefreshManager) GenerateBatch(payloads []config.Payload) ([]*token.TokenPair, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateBatch", payloads)
+	ret0, _ := ret[0].([]*token.TokenPair)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// GenerateBatch indicates an expected call of GenerateBatch.
+func (mr *MockRefreshManagerMockRecorder) GenerateBatch(payloads interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateBatch", reflect.TypeOf((*MockRefreshManager)(nil).GenerateBatch), payloads)
+}
+
+// ParseRefreshToken mocks base method.
+func (m *MockRefreshManager) ParseRefreshToken(refreshToken string) (*config.Payload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseRefreshToken", refreshToken)
+	ret0, _ := ret[0].(*config.Payload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseRefreshToken indicates an expected call of ParseRefreshToken.
+func (mr *MockRefreshManagerMockRecorder) ParseRefreshToken(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseRefreshToken", reflect.TypeOf((*MockRefreshManager)(nil).ParseRefreshToken), refreshToken)
+}
+
+// Refresh mocks base method.
+func (m *MockRefreshManager) Refresh(ctx context.Context, refreshToken string) (*token.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(*token.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockRefreshManagerMockRecorder) Refresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRefreshManager)(nil).Refresh), ctx, refreshToken)
+}
+
+// Revoke mocks base method.
+func (m *MockRefreshManager) Revoke(ctx context.Context, payload *config.Payload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRefreshManagerMockRecorder) Revoke(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRefreshManager)(nil).Revoke), ctx, payload)
+}
@@ -49,6 +49,21 @@ func (mr *MockManagerMockRecorder) Generate(payload interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockManager)(nil).Generate), payload)
 }
 
+// GenerateBatch mocks base method.
+func (m *MockManager) GenerateBatch(payloads []config.Payload) ([]string, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateBatch", payloads)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// GenerateBatch indicates an expected call of GenerateBatch.
+func (mr *MockManagerMockRecorder) GenerateBatch(payloads interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateBatch", reflect.TypeOf((*MockManager)(nil).GenerateBatch), payloads)
+}
+
 // Parse mocks base method.
 func (m *MockManager) Parse(token string) (*config.Payload, error) {
 	m.ctrl.T.Helper()